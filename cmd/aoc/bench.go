@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"aoc2024/internal/bench"
+	"aoc2024/internal/run"
+	"aoc2024/pkg/flags"
+	"aoc2024/pkg/log"
+)
+
+// benchmark runs the selected days repeatedly and prints their statistics, returns the exit code
+func benchmark(ctx context.Context, opts flags.FlagBenchOpts) int {
+	// Logging would distort the timings
+	log.Disable()
+
+	days, err := run.SelectDays(opts.Year, opts.Days.All, opts.Days.List)
+	if err != nil {
+		return exitError(err)
+	}
+	input := run.Input{File: opts.File, Dir: opts.Inputs}
+	if err := input.Validate(days); err != nil {
+		return exitError(err)
+	}
+
+	ctx, cancel := withTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	stats := []bench.Stats{}
+	for _, day := range days {
+		dayStats, err := bench.Day(ctx, opts.Year, day, input.Filename(opts.Year, day), int(opts.Part), opts.Runs, opts.ReadOptions()...)
+		if err != nil {
+			return exitError(err)
+		}
+		stats = append(stats, dayStats...)
+	}
+
+	if err := bench.PrintStats(os.Stdout, stats); err != nil {
+		return exitError(err)
+	}
+	return exitOK
+}
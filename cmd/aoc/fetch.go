@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"aoc2024/internal/client"
+	"aoc2024/internal/config"
+	"aoc2024/internal/run"
+	"aoc2024/pkg/flags"
+	"aoc2024/pkg/registry"
+)
+
+// fetch downloads the puzzle inputs of the selected days that are not cached yet, returns the exit code
+func fetch(ctx context.Context, opts flags.FlagFetchOpts, cfg config.Config) int {
+	days := opts.Days.List
+	if opts.Days.All {
+		if days = registry.Days(opts.Year); len(days) == 0 {
+			return exitError(fmt.Errorf("no days registered for %d", opts.Year))
+		}
+	}
+
+	input := run.Input{File: opts.File, Dir: opts.Inputs}
+	if err := input.Validate(days); err != nil {
+		return exitError(err)
+	}
+
+	ctx, cancel := withTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	c := client.New(cfg.BaseURL, cfg.Session)
+	for _, day := range days {
+		file := input.Filename(opts.Year, day)
+		fetched, err := c.FetchInput(ctx, opts.Year, day, file)
+		if err != nil {
+			return exitError(err)
+		}
+		if fetched {
+			fmt.Println("fetched", file)
+		} else {
+			fmt.Println("cached", file)
+		}
+	}
+	return exitOK
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"aoc2024/pkg/registry"
+)
+
+const tablePadding = 2
+
+// list prints every registered day with its metadata, returns the exit code
+func list() int {
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, tablePadding, ' ', 0)
+	fmt.Fprintln(table, "YEAR\tDAY\tTITLE\tPARTS\tTAGS")
+
+	for _, entry := range registry.Entries() {
+		fmt.Fprintf(table, "%d\t%d\t%s\t%d\t%s\n",
+			entry.Year, entry.Day, entry.Title, entry.Parts, strings.Join(entry.Tags, ","),
+		)
+	}
+
+	if err := table.Flush(); err != nil {
+		return exitError(err)
+	}
+	return exitOK
+}
@@ -1,18 +1,203 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	_ "aoc2024/days" // Register every solved day
+	"aoc2024/internal/config"
+	"aoc2024/internal/output"
+	"aoc2024/internal/profile"
 	"aoc2024/internal/run"
 	"aoc2024/pkg/flags"
 	"aoc2024/pkg/log"
 )
 
+// Exit codes of the CLI
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+)
+
 func main() {
-	opts := flags.Parse()
+	// Ctrl-C cancels the running solver, a second Ctrl-C kills the process
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	go func() {
+		// restore the default handling of Ctrl-C once the first one canceled ctx
+		<-ctx.Done()
+		stop()
+	}()
+	code := execute(ctx, os.Args[1:])
+	stop()
+	os.Exit(code)
+}
+
+// withTimeout returns ctx cut off after timeout, no timeout when zero
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// execute runs the command selected by args, returns the exit code
+func execute(ctx context.Context, args []string) int {
+	command, args := flags.Split(args)
+
+	// flags default to the config file and AOC_* environment variables
+	cfg := config.Default()
+	switch command {
+	case flags.Run, flags.Bench, flags.Verify, flags.Watch, flags.Serve, flags.Fetch, flags.Submit:
+		var err error
+		if cfg, err = config.Load(os.LookupEnv); err != nil {
+			return exitError(err)
+		}
+	}
+
+	switch command {
+	case flags.Run:
+		opts, err := flags.ParseRun(args, os.Stderr, cfg)
+		if err != nil {
+			return usageError(err)
+		}
+		return profiled(opts.Profile, func() int {
+			return solve(ctx, opts)
+		})
+	case flags.Bench:
+		opts, err := flags.ParseBench(args, os.Stderr, cfg)
+		if err != nil {
+			return usageError(err)
+		}
+		return profiled(opts.Profile, func() int {
+			return benchmark(ctx, opts)
+		})
+	case flags.Verify:
+		opts, err := flags.ParseVerify(args, os.Stderr, cfg)
+		if err != nil {
+			return usageError(err)
+		}
+		return verifyAnswers(ctx, opts)
+	case flags.Watch:
+		opts, err := flags.ParseWatch(args, os.Stderr, cfg)
+		if err != nil {
+			return usageError(err)
+		}
+		return watchDay(ctx, opts)
+	case flags.Serve:
+		opts, err := flags.ParseServe(args, os.Stderr, cfg)
+		if err != nil {
+			return usageError(err)
+		}
+		return serve(ctx, opts)
+	case flags.Fetch:
+		opts, err := flags.ParseFetch(args, os.Stderr, cfg)
+		if err != nil {
+			return usageError(err)
+		}
+		return fetch(ctx, opts, cfg)
+	case flags.Submit:
+		opts, err := flags.ParseSubmit(args, os.Stderr, cfg)
+		if err != nil {
+			return usageError(err)
+		}
+		return submit(ctx, opts, cfg)
+	case flags.New:
+		opts, err := flags.ParseNew(args, os.Stderr)
+		if err != nil {
+			return usageError(err)
+		}
+		return newDay(opts)
+	case flags.List, flags.Version:
+		if err := flags.ParseNoFlags(command, args, os.Stderr); err != nil {
+			return usageError(err)
+		}
+		if command == flags.List {
+			return list()
+		}
+		return printVersion()
+	case flags.Help:
+		flags.Usage(os.Stdout)
+		return exitOK
+	case "":
+		flags.Usage(os.Stderr)
+		return exitUsage
+	}
+
+	fmt.Fprintf(os.Stderr, "unknown command %q\n\n", command)
+	flags.Usage(os.Stderr)
+	return exitUsage
+}
 
-	// Setting debug level
-	if opts.Debug {
-		log.InitializeLogger(log.WithLevel(log.DebugLevel))
+// usageError returns the exit code of a failed parse, the usage has already been written
+func usageError(err error) int {
+	if errors.Is(err, flags.ErrHelp) {
+		return exitOK
 	}
+	return exitUsage
+}
+
+// exitError prints err to stderr and returns the failure exit code
+func exitError(err error) int {
+	fmt.Fprintln(os.Stderr, err)
+	return exitFailure
+}
+
+// profiled runs the command fn while writing the profiles of opts, returns the exit code of fn
+func profiled(opts profile.Options, fn func() int) int {
+	profiler, err := profile.Start(opts)
+	if err != nil {
+		_ = profiler.Stop()
+		return exitError(err)
+	}
+
+	code := fn()
+	if err := profiler.Stop(); err != nil {
+		return exitError(err)
+	}
+	return code
+}
+
+// solve runs the selected days and writes the results, returns the exit code
+func solve(ctx context.Context, opts flags.FlagRunOpts) int {
+	setupLogger(opts.FlagLogOpts)
+
+	reports, err := solveDays(ctx, opts.FlagInputOpts, opts.Workers)
+	if err != nil {
+		return exitError(err)
+	}
+
+	if err := output.Write(os.Stdout, opts.Output, reports); err != nil {
+		return exitError(err)
+	}
+
+	for _, report := range reports {
+		if report.Failed() {
+			return exitFailure
+		}
+	}
+	return exitOK
+}
+
+// setupLogger configures the logger with the level and format of opts
+func setupLogger(opts flags.FlagLogOpts) {
+	level, _ := log.ParseLevel(opts.LogLevel) // validated with the flags
+	log.InitializeLogger(log.WithLevel(level), log.WithFormat(opts.LogFormat))
+}
+
+// solveDays solves the selected days within the timeout, workers days at a time
+func solveDays(ctx context.Context, opts flags.FlagInputOpts, workers int) ([]run.Report, error) {
+	days, err := run.SelectDays(opts.Year, opts.Days.All, opts.Days.List)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := withTimeout(ctx, opts.Timeout)
+	defer cancel()
 
-	run.Day(opts.Day, opts.File)
+	return run.All(ctx, opts.Year, days, run.Input{File: opts.File, Dir: opts.Inputs, Options: opts.ReadOptions()}, int(opts.Part), workers)
 }
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+
+	"aoc2024/internal/scaffold"
+	"aoc2024/pkg/flags"
+	"aoc2024/pkg/registry"
+)
+
+// newDay generates and registers a new day package, returns the exit code
+func newDay(opts flags.FlagNewOpts) int {
+	if _, ok := registry.Lookup(opts.Year, opts.Day); ok {
+		return exitError(fmt.Errorf("%d day %d is already registered", opts.Year, opts.Day))
+	}
+
+	created, err := scaffold.New(opts.Root, scaffold.Day{
+		Year:  opts.Year,
+		Day:   opts.Day,
+		Title: opts.Title,
+	})
+	for _, file := range created {
+		fmt.Println("created", file)
+	}
+	if err != nil {
+		return exitError(err)
+	}
+	return exitOK
+}
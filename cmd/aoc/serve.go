@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"aoc2024/internal/server"
+	"aoc2024/pkg/flags"
+	"aoc2024/pkg/log"
+)
+
+const (
+	readHeaderTimeout = 10 * time.Second
+	shutdownTimeout   = 5 * time.Second
+)
+
+// serve serves the solvers over HTTP until interrupted, returns the exit code
+func serve(ctx context.Context, opts flags.FlagServeOpts) int {
+	setupLogger(opts.FlagLogOpts)
+
+	srv := &http.Server{
+		Addr:              opts.Addr,
+		Handler:           server.New(opts.Timeout),
+		ReadHeaderTimeout: readHeaderTimeout,
+		// requests are canceled on interrupt so their running solvers stop
+		BaseContext: func(net.Listener) context.Context {
+			return ctx
+		},
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- srv.ListenAndServe()
+	}()
+	log.Info("Serving HTTP API", log.String("addr", opts.Addr))
+
+	select {
+	case err := <-errs:
+		return exitError(err)
+	case <-ctx.Done():
+	}
+
+	// solvers not polling their context may outlive the shutdown timeout, their connections are closed
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	err := srv.Shutdown(shutdownCtx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		log.Warn("Closing requests still running after shutdown timeout", log.String("timeout", shutdownTimeout.String()))
+		err = srv.Close()
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return exitError(err)
+	}
+	return exitOK
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"aoc2024/internal/client"
+	"aoc2024/internal/config"
+	"aoc2024/internal/run"
+	"aoc2024/pkg/flags"
+)
+
+// throttleFile remembers in the inputs directory until when answers are refused
+const throttleFile = ".submit-wait"
+
+// submit solves the selected part of a day and submits its answer, returns the exit code
+func submit(ctx context.Context, opts flags.FlagSubmitOpts, cfg config.Config) int {
+	setupLogger(opts.FlagLogOpts)
+	day, part := opts.Days.List[0], int(opts.Part) // validated with the flags
+
+	answer, err := solvePart(ctx, opts.FlagInputOpts, day, part)
+	if err != nil {
+		return exitError(err)
+	}
+
+	c := client.New(cfg.BaseURL, cfg.Session)
+	c.Throttle = client.Throttle{File: filepath.Join(opts.Inputs, throttleFile)}
+	verdict, err := c.Submit(ctx, opts.Year, day, part, answer)
+	if err != nil {
+		return exitError(err)
+	}
+
+	fmt.Printf("%d day %d part %d: %s is %s\n", opts.Year, day, part, answer, verdict.Status)
+	if verdict.Wait > 0 {
+		fmt.Printf("wait %s before the next answer\n", verdict.Wait)
+	}
+	if verdict.Status != client.Correct && verdict.Status != client.AlreadySolved {
+		return exitFailure
+	}
+	return exitOK
+}
+
+// solvePart solves part of day within the timeout and returns its answer
+func solvePart(ctx context.Context, opts flags.FlagInputOpts, day, part int) (string, error) {
+	ctx, cancel := withTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	report, err := run.Day(ctx, opts.Year, day, run.Input{File: opts.File, Dir: opts.Inputs}.Filename(opts.Year, day), part, opts.ReadOptions()...)
+	if err != nil {
+		return "", err
+	}
+	for _, result := range report.Results {
+		if result.Err != nil {
+			return "", fmt.Errorf("%d day %d %s: %w", opts.Year, day, result.Stage(), result.Err)
+		}
+		if result.Part == part && !result.Answer.IsZero() {
+			return result.Answer.String(), nil
+		}
+	}
+	return "", errors.New("the solver gave no answer")
+}
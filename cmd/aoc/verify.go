@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"aoc2024/internal/verify"
+	"aoc2024/pkg/flags"
+)
+
+// verifyAnswers runs the selected days and compares them with the expected answers, returns the exit code
+func verifyAnswers(ctx context.Context, opts flags.FlagVerifyOpts) int {
+	setupLogger(opts.FlagLogOpts)
+
+	file := opts.Answers
+	if file == "" {
+		file = verify.Filename(opts.Inputs, opts.Year)
+	}
+	answers, err := verify.Load(file)
+	if err != nil {
+		return exitError(err)
+	}
+
+	reports, err := solveDays(ctx, opts.FlagInputOpts, opts.Workers)
+	if err != nil {
+		return exitError(err)
+	}
+
+	checks := verify.Verify(answers, reports)
+	if err := verify.PrintChecks(os.Stdout, checks); err != nil {
+		return exitError(err)
+	}
+
+	if verify.Failed(checks) {
+		return exitFailure
+	}
+	return exitOK
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+)
+
+// version of the binary, set when building with -ldflags "-X main.version=v1.0.0"
+var version = ""
+
+// printVersion prints the version, module version and VCS revision of the binary, returns the exit code
+func printVersion() int {
+	v, revision := version, ""
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if v == "" {
+			v = info.Main.Version
+		}
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" {
+				revision = setting.Value
+			}
+		}
+	}
+	if v == "" {
+		v = "(devel)"
+	}
+
+	if revision != "" {
+		fmt.Printf("aoc %s %s %s\n", v, revision, runtime.Version())
+	} else {
+		fmt.Printf("aoc %s %s\n", v, runtime.Version())
+	}
+	return exitOK
+}
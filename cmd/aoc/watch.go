@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	"aoc2024/internal/run"
+	"aoc2024/internal/watch"
+	"aoc2024/pkg/flags"
+)
+
+// watchDay solves a day again whenever its sources or puzzle input change until interrupted, returns the exit code
+func watchDay(ctx context.Context, opts flags.FlagWatchOpts) int {
+	day := opts.Days.List[0]
+	watcher := &watch.Watcher{
+		Root:     opts.Root,
+		Year:     opts.Year,
+		Day:      day,
+		Part:     int(opts.Part),
+		File:     run.Input{File: opts.File, Dir: opts.Inputs}.Filename(opts.Year, day),
+		Timeout:  opts.Timeout,
+		Interval: opts.Interval,
+		RunArgs:  []string{"-mmap=" + strconv.FormatBool(opts.Mmap), "-max-line-size", strconv.Itoa(opts.MaxLineSize)},
+		Out:      os.Stdout,
+		Err:      os.Stderr,
+	}
+	if err := watcher.Run(ctx); err != nil {
+		return exitError(err)
+	}
+	return exitOK
+}
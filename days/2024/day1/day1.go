@@ -1,17 +1,16 @@
 package day1
 
 import (
-	"bufio"
-	"errors"
-	"fmt"
-	"os"
+	"context"
+	"io"
 	"sort"
-	"strconv"
-	"strings"
 	"sync"
 
 	"aoc2024/pkg/log"
 	"aoc2024/pkg/math"
+	"aoc2024/pkg/reader"
+	"aoc2024/pkg/registry"
+	"aoc2024/pkg/solver"
 )
 
 func sortList(wg *sync.WaitGroup, l []int) {
@@ -22,54 +21,33 @@ func sortList(wg *sync.WaitGroup, l []int) {
 	})
 }
 
-func ExtractSplitList(filename string) ([]int, []int, error) {
+// LocationIDs is a line of the puzzle input holding a location ID of both lists
+type LocationIDs struct {
+	Left  int
+	Right int
+}
+
+var locationIDs = reader.MustRecord[LocationIDs]("{Left} {Right}")
+
+func ExtractSplitList(r io.Reader) ([]int, []int, error) {
 	var (
 		left  []int
 		right []int
 	)
-	file, err := os.Open(filename)
+
+	lines, err := reader.NewInput(r).Lines()
 	if err != nil {
 		return left, right, err
 	}
 
-	defer file.Close()
-
-	filescanner := bufio.NewScanner(file)
-	filescanner.Split(bufio.ScanLines)
-
-	for filescanner.Scan() {
-		line := filescanner.Text()
-		v := strings.Split(line, "   ")
-
-		if len(v) != 2 {
-			log.Error("Error failed to split line",
-				log.Any("Split-list", v),
-				log.String("line", line),
-			)
-			return left, right, errors.New("Failed to splits list by two")
-		}
-
-		// Convert string to int and append to left
-		l, err := strconv.Atoi(v[0])
-		if err != nil {
-			log.Error("Error failed convert integer",
-				log.String("Value", v[0]),
-				log.Any("Split-list", v),
-			)
-			return left, right, err
-		}
-		left = append(left, l)
-
-		// Convert string to int and append to left
-		r, err := strconv.Atoi(v[1])
-		if err != nil {
-			log.Error("Error failed convert integer",
-				log.String("Value", v[1]),
-				log.Any("Split-list", v),
-			)
-			return left, right, err
-		}
-		right = append(right, r)
+	ids, err := locationIDs.ParseLines(lines, 1)
+	if err != nil {
+		log.Error("Error failed to split lists", log.String("error", err.Error()))
+		return left, right, err
+	}
+	for _, id := range ids {
+		left = append(left, id.Left)
+		right = append(right, id.Right)
 	}
 	return left, right, nil
 }
@@ -141,11 +119,34 @@ func similarityScore(left, right []int) int {
 	return score
 }
 
-func AdventSolveDay1(filename string) {
-	left, right, err := ExtractSplitList(filename)
+func init() {
+	registry.Register(registry.Entry{
+		Year:  2024,
+		Day:   1,
+		Title: "Historian Hysteria",
+		Parts: 2,
+		Tags:  []string{"sorting"},
+		New:   NewSolver,
+	})
+}
+
+// Solver solves day 1 Historian Hysteria
+type Solver struct {
+	left  []int
+	right []int
+}
 
+// NewSolver returns a new day 1 solver
+func NewSolver() solver.Solver {
+	return &Solver{}
+}
+
+// Parse extracts both location lists and sorts them
+func (s *Solver) Parse(r io.Reader) error {
+	left, right, err := ExtractSplitList(r)
 	if err != nil {
-		log.Fatal(err.Error(), log.String("filename", filename))
+		log.Error(err.Error())
+		return err
 	}
 
 	// Go routine sort both lists
@@ -156,13 +157,22 @@ func AdventSolveDay1(filename string) {
 	go sortList(&wg, right)
 	wg.Wait()
 
-	log.Info("Start Part 1", log.String("filename", filename))
-	total := totalDistance(left, right)
-	fmt.Println("Part 1 Total Distance:", total)
-	log.Info("Part 1 Done", log.String("filename", filename), log.Int("Total", total))
+	s.left, s.right = left, right
+	return nil
+}
+
+// Part1 total distance between the lists
+func (s *Solver) Part1(_ context.Context) (solver.Answer, error) {
+	log.Info("Start Part 1")
+	total := totalDistance(s.left, s.right)
+	log.Info("Part 1 Done", log.Int("Total", total))
+	return solver.Int(total), nil
+}
 
-	log.Info("Start Part 2", log.String("filename", filename))
-	score := similarityScore(left, right)
-	fmt.Println("Part 2 Similarity Score:", score)
-	log.Info("Part 2 Done", log.String("filename", filename), log.Int("Score", score))
+// Part2 similarity score of the lists
+func (s *Solver) Part2(_ context.Context) (solver.Answer, error) {
+	log.Info("Start Part 2")
+	score := similarityScore(s.left, s.right)
+	log.Info("Part 2 Done", log.Int("Score", score))
+	return solver.Int(score), nil
 }
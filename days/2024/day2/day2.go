@@ -1,48 +1,27 @@
 package day2
 
 import (
-	"bufio"
-	"fmt"
-	"os"
-	"strconv"
-	"strings"
+	"context"
+	"io"
 
 	"aoc2024/pkg/log"
+	"aoc2024/pkg/reader"
+	"aoc2024/pkg/registry"
+	"aoc2024/pkg/solver"
 )
 
-func extractReports(filename string) [][]int {
-	file, err := os.Open(filename)
+func extractReports(r io.Reader) ([][]int, error) {
+	reports, err := reader.NewInput(r).IntFields()
 	if err != nil {
-		log.Fatal("Failed to open file",
-			log.String("filename", filename),
-			log.String("error", err.Error()),
-		)
+		log.Error("Cannot convert level to int", log.String("error", err.Error()))
+		return reports, err
 	}
-	defer file.Close()
-
-	lines := bufio.NewScanner(file)
-	lines.Split(bufio.ScanLines)
-
-	reports := [][]int{}
-	for lines.Scan() {
-		line := lines.Text()
-		levels := strings.Split(line, " ")
-
-		report := []int{}
-		for _, level := range levels {
-			v, err := strconv.Atoi(level)
-			if err != nil {
-				log.Fatal("Cannot convert level to int",
-					log.String("level", level),
-					log.String("error", err.Error()),
-					log.Any("levels", levels),
-				)
-			}
-			report = append(report, v)
+	for i, report := range reports {
+		if len(report) == 0 {
+			return nil, reader.Errorf(i+1, 0, "expected levels, got a blank line")
 		}
-		reports = append(reports, report)
 	}
-	return reports
+	return reports, nil
 }
 
 type ReportSafetySystemFunc func(report []int) bool
@@ -57,6 +36,11 @@ func safetyLevelcheck(report []int, ruleBreak func(i, j int) bool) bool {
 }
 
 func reportSafetySystemCheck(report []int) bool {
+	// A single level is neither increasing nor decreasing, it breaks no rule e.g. when dampened from two levels
+	if len(report) < 2 {
+		return true
+	}
+
 	// Either level increasing nor decreasing
 	if report[0] == report[1] {
 		return false
@@ -77,8 +61,7 @@ func reportSafetySystemCheck(report []int) bool {
 	return safetyLevelcheck(report, ruleFunc)
 }
 
-func countSafeReports(filename string, safetySystemFunc ReportSafetySystemFunc) int {
-	reports := extractReports(filename)
+func countSafeReports(reports [][]int, safetySystemFunc ReportSafetySystemFunc) int {
 	safeCountch := make(chan int, len(reports))
 
 	for _, report := range reports {
@@ -101,8 +84,7 @@ func countSafeReports(filename string, safetySystemFunc ReportSafetySystemFunc)
 	return safeCount
 }
 
-func countSafeReportsWithDampener(filename string, safetySystemFunc ReportSafetySystemFunc) int {
-	reports := extractReports(filename)
+func countSafeReportsWithDampener(reports [][]int, safetySystemFunc ReportSafetySystemFunc) int {
 	safeCountch := make(chan int, len(reports))
 
 	for _, report := range reports {
@@ -130,14 +112,49 @@ func countSafeReportsWithDampener(filename string, safetySystemFunc ReportSafety
 	return safeCount
 }
 
-func AdventSolveDay2(filename string) {
-	log.Info("Start Part 1", log.String("filename", filename))
-	safe := countSafeReports(filename, reportSafetySystemCheck)
-	fmt.Println("Safe Part 1:", safe)
-	log.Info("Part 1 Done", log.String("filename", filename), log.Int("Safe", safe))
+func init() {
+	registry.Register(registry.Entry{
+		Year:  2024,
+		Day:   2,
+		Title: "Red-Nosed Reports",
+		Parts: 2,
+		Tags:  []string{"parsing"},
+		New:   NewSolver,
+	})
+}
+
+// Solver solves day 2 Red-Nosed Reports
+type Solver struct {
+	reports [][]int
+}
+
+// NewSolver returns a new day 2 solver
+func NewSolver() solver.Solver {
+	return &Solver{}
+}
+
+// Parse extracts the reports
+func (s *Solver) Parse(r io.Reader) error {
+	reports, err := extractReports(r)
+	if err != nil {
+		return err
+	}
+	s.reports = reports
+	return nil
+}
+
+// Part1 count of safe reports
+func (s *Solver) Part1(_ context.Context) (solver.Answer, error) {
+	log.Info("Start Part 1")
+	safe := countSafeReports(s.reports, reportSafetySystemCheck)
+	log.Info("Part 1 Done", log.Int("Safe", safe))
+	return solver.Int(safe), nil
+}
 
-	log.Info("Start Part 2", log.String("filename", filename))
-	safeWithDampeners := countSafeReportsWithDampener(filename, reportSafetySystemCheck)
-	fmt.Println("Safe with Dampeners:", safeWithDampeners)
-	log.Info("Part 2 Done", log.String("filename", filename), log.Int("Safe", safeWithDampeners))
+// Part2 count of safe reports with the Problem Dampener
+func (s *Solver) Part2(_ context.Context) (solver.Answer, error) {
+	log.Info("Start Part 2")
+	safeWithDampeners := countSafeReportsWithDampener(s.reports, reportSafetySystemCheck)
+	log.Info("Part 2 Done", log.Int("Safe", safeWithDampeners))
+	return solver.Int(safeWithDampeners), nil
 }
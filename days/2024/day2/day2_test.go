@@ -0,0 +1,50 @@
+package day2_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	_ "aoc2024/days/2024/day2" // Register 2024 day 2
+	"aoc2024/internal/daytest"
+	"aoc2024/pkg/registry"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExamples tests the 2024 day 2 solver against the examples in testdata
+func TestExamples(t *testing.T) {
+	daytest.Run(t, 2024, 2)
+}
+
+// TestInput tests the 2024 day 2 solver against the real puzzle input, see daytest.InputsEnv
+func TestInput(t *testing.T) {
+	daytest.RunInput(t, 2024, 2)
+}
+
+// TestParseBlankLine tests that a blank line between reports is a parse error
+func TestParseBlankLine(t *testing.T) {
+	entry, ok := registry.Lookup(2024, 2)
+	require.True(t, ok)
+
+	err := entry.New().Parse(strings.NewReader("7 6 4 2 1\n\n1 3 2 4 5\n"))
+	assert.EqualError(t, err, "line 2: expected levels, got a blank line")
+}
+
+// TestShortReports tests that reports of one or two levels are solved, dampening two levels leaves one
+func TestShortReports(t *testing.T) {
+	entry, ok := registry.Lookup(2024, 2)
+	require.True(t, ok)
+
+	s := entry.New()
+	require.NoError(t, s.Parse(strings.NewReader("7 6 4 2 1\n1 9\n5\n")))
+
+	part1, err := s.Part1(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "2", part1.String())
+
+	part2, err := s.Part2(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "3", part2.String())
+}
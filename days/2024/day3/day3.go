@@ -0,0 +1,140 @@
+package day3
+
+import (
+	"context"
+	"io"
+
+	"aoc2024/pkg/log"
+	"aoc2024/pkg/parse"
+	"aoc2024/pkg/reader"
+	"aoc2024/pkg/registry"
+	"aoc2024/pkg/solver"
+)
+
+// Op is the operation of an instruction
+type Op int
+
+const (
+	Mul Op = iota
+	Do
+	Dont
+)
+
+// Instruction found in the corrupted memory, Product is the result of a Mul
+type Instruction struct {
+	Op      Op
+	Product int
+}
+
+var (
+	// mul parses mul(X,Y) where X and Y are 1-3 digit numbers
+	mul = parse.Between(
+		parse.Literal("mul("),
+		parse.Seq3(parse.Digits(1, 3), parse.Literal(","), parse.Digits(1, 3), func(x int, _ string, y int) Instruction {
+			return Instruction{Op: Mul, Product: x * y}
+		}),
+		parse.Literal(")"),
+	)
+	instruction = parse.Alt(
+		mul,
+		parse.Map(parse.Literal("do()"), func(string) Instruction { return Instruction{Op: Do} }),
+		parse.Map(parse.Literal("don't()"), func(string) Instruction { return Instruction{Op: Dont} }),
+	)
+	// memory finds the instructions in the corrupted memory, they start with m or d
+	memory = parse.ScanAt("md", instruction)
+)
+
+func extractMemory(r io.Reader) ([]parse.Located[Instruction], error) {
+	text, err := reader.NewInput(r).Text()
+	if err != nil {
+		return nil, err
+	}
+	return parse.Parse(memory, text)
+}
+
+func decorruptMemory(instructions []parse.Located[Instruction]) int {
+	sum := 0
+	for _, in := range instructions {
+		if in.Value.Op == Mul {
+			sum += in.Value.Product
+			log.Debug("Multiplication added", log.Int("line", in.Pos.Line), log.Int("column", in.Pos.Column), log.Int("mul", in.Value.Product), log.Int("sum", sum))
+		}
+	}
+
+	return sum
+}
+
+func decorruptMemoryOperations(instructions []parse.Located[Instruction]) int {
+	var (
+		sum = 0
+		do  = true
+	)
+	for _, in := range instructions {
+		switch in.Value.Op {
+		case Do:
+			do = true
+			log.Debug("do() toggle on", log.Int("line", in.Pos.Line), log.Int("column", in.Pos.Column), log.Bool("do", do))
+		case Dont:
+			do = false
+			log.Debug("don't() toggle off", log.Int("line", in.Pos.Line), log.Int("column", in.Pos.Column), log.Bool("do", do))
+		case Mul:
+			if do {
+				sum += in.Value.Product
+				log.Debug("multiplication added",
+					log.Int("line", in.Pos.Line), log.Int("column", in.Pos.Column),
+					log.Int("mul", in.Value.Product),
+					log.Int("sum", sum),
+				)
+			}
+		}
+	}
+
+	return sum
+}
+
+func init() {
+	registry.Register(registry.Entry{
+		Year:  2024,
+		Day:   3,
+		Title: "Mull It Over",
+		Parts: 2,
+		Tags:  []string{"parsing", "grammar"},
+		New:   NewSolver,
+	})
+}
+
+// Solver solves day 3 Mull It Over
+type Solver struct {
+	instructions []parse.Located[Instruction]
+}
+
+// NewSolver returns a new day 3 solver
+func NewSolver() solver.Solver {
+	return &Solver{}
+}
+
+// Parse extracts the corrupted memory
+func (s *Solver) Parse(r io.Reader) error {
+	instructions, err := extractMemory(r)
+	if err != nil {
+		return err
+	}
+	s.instructions = instructions
+	return nil
+}
+
+// Part1 sum of all multiplications
+func (s *Solver) Part1(_ context.Context) (solver.Answer, error) {
+	log.Info("Start Part 1")
+	sum := decorruptMemory(s.instructions)
+	log.Info("Done Part 1", log.Int("multiply-sum", sum))
+	return solver.Int(sum), nil
+}
+
+// Part2 sum of all enabled multiplications
+func (s *Solver) Part2(_ context.Context) (solver.Answer, error) {
+	log.Info("Start Part 2")
+	sum := decorruptMemoryOperations(s.instructions)
+	log.Info("Done Part 2", log.Int("multiply-sum", sum))
+	return solver.Int(sum), nil
+}
@@ -1,25 +1,28 @@
 package day4
 
 import (
-	"fmt"
+	"context"
+	"io"
 
 	"aoc2024/pkg/log"
 	"aoc2024/pkg/reader"
+	"aoc2024/pkg/registry"
+	"aoc2024/pkg/solver"
 )
 
 const XMAS = "XMAS"
 
 type Xmas struct {
-	text    []string
+	text    reader.Grid
 	yLength int
 	xLength int
 }
 
-func New(text []string) *Xmas {
+func New(text reader.Grid) *Xmas {
 	return &Xmas{
 		text:    text,
-		yLength: len(text),
-		xLength: len(text[0]),
+		yLength: text.Height(),
+		xLength: text.Width(),
 	}
 }
 
@@ -45,7 +48,7 @@ func (xmas *Xmas) searchForAllXMAS() int {
 	isXMAS := func(x, y, dx, dy int) bool {
 		for i := 0; i < len(XMAS); i++ {
 			nx, ny := x+i*dx, y+i*dy
-			if nx < 0 || ny < 0 || nx >= xmas.yLength || ny >= xmas.xLength || xmas.text[nx][ny] != XMAS[i] {
+			if !xmas.text.In(nx, ny) || xmas.text[ny][nx] != XMAS[i] {
 				return false
 			}
 		}
@@ -159,24 +162,51 @@ func (xmas *Xmas) searchAllXXMAS() int {
 	return count
 }
 
-func AdventSolveDay4(filename string) {
-	text, err := reader.FileReadlines(filename)
+func init() {
+	registry.Register(registry.Entry{
+		Year:  2024,
+		Day:   4,
+		Title: "Ceres Search",
+		Parts: 2,
+		Tags:  []string{"grid", "search"},
+		New:   NewSolver,
+	})
+}
+
+// Solver solves day 4 Ceres Search
+type Solver struct {
+	xmas *Xmas
+}
+
+// NewSolver returns a new day 4 solver
+func NewSolver() solver.Solver {
+	return &Solver{}
+}
+
+// Parse reads the word search
+func (s *Solver) Parse(r io.Reader) error {
+	text, err := reader.NewInput(r).Grid()
 	if err != nil {
-		log.Fatal("Failed to read file",
-			log.String("error", err.Error()),
-			log.String("filename", filename),
-		)
+		log.Error("Failed to read word search", log.String("error", err.Error()))
+		return err
 	}
 
-	xmas := New(text)
+	s.xmas = New(text)
+	return nil
+}
 
-	log.Info("Start Part 1", log.String("filename", filename))
-	foundAllXMAS := xmas.searchForAllXMAS()
-	fmt.Println("Found XMAS:", foundAllXMAS)
-	log.Info("Done Part 1", log.String("filename", filename))
+// Part1 count of XMAS in the word search
+func (s *Solver) Part1(_ context.Context) (solver.Answer, error) {
+	log.Info("Start Part 1")
+	foundAllXMAS := s.xmas.searchForAllXMAS()
+	log.Info("Done Part 1", log.Int("XMAS", foundAllXMAS))
+	return solver.Int(foundAllXMAS), nil
+}
 
-	log.Info("Start Part 2", log.String("filename", filename))
-	foundAllXXMAS := xmas.searchAllXXMAS()
-	fmt.Println("Found X-MAS:", foundAllXXMAS)
-	log.Info("Done Part 2", log.String("filename", filename))
+// Part2 count of X-MAS in the word search
+func (s *Solver) Part2(_ context.Context) (solver.Answer, error) {
+	log.Info("Start Part 2")
+	foundAllXXMAS := s.xmas.searchAllXXMAS()
+	log.Info("Done Part 2", log.Int("X-MAS", foundAllXXMAS))
+	return solver.Int(foundAllXXMAS), nil
 }
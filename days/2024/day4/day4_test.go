@@ -0,0 +1,39 @@
+package day4_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	_ "aoc2024/days/2024/day4" // Register 2024 day 4
+	"aoc2024/internal/daytest"
+	"aoc2024/pkg/registry"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExamples tests the 2024 day 4 solver against the examples in testdata
+func TestExamples(t *testing.T) {
+	daytest.Run(t, 2024, 4)
+}
+
+// TestInput tests the 2024 day 4 solver against the real puzzle input, see daytest.InputsEnv
+func TestInput(t *testing.T) {
+	daytest.RunInput(t, 2024, 4)
+}
+
+// TestWordSearchGrid tests that ragged word searches are parse errors and that tall ones are searched entirely
+func TestWordSearchGrid(t *testing.T) {
+	entry, ok := registry.Lookup(2024, 4)
+	require.True(t, ok)
+
+	err := entry.New().Parse(strings.NewReader("XMAS\nXM\nXMAS\nXMAS\n"))
+	assert.EqualError(t, err, "line 2: row has 2 columns, expected 4")
+
+	s := entry.New()
+	require.NoError(t, s.Parse(strings.NewReader("X.\nM.\nA.\nS.\n")))
+	part1, err := s.Part1(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "1", part1.String())
+}
@@ -0,0 +1,266 @@
+package day5
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"strconv"
+
+	"aoc2024/pkg/log"
+	"aoc2024/pkg/reader"
+	"aoc2024/pkg/registry"
+	"aoc2024/pkg/solver"
+)
+
+type Rules map[string]bool
+
+type Update struct {
+	page []string
+}
+
+const Two = 2
+
+// Copy returns a copy of the update, patching the copy leaves the original pages untouched.
+func (u Update) Copy() Update {
+	page := make([]string, len(u.page))
+	copy(page, u.page)
+	return Update{page: page}
+}
+
+// rule is a page ordering rule, page Before must be printed before page After
+type rule struct {
+	Before int
+	After  int
+}
+
+// pages is the pages to produce in an update
+type pages struct {
+	Pages []int
+}
+
+var (
+	ruleRecord  = reader.MustRecord[rule]("{Before}|{After}")
+	pagesRecord = reader.MustRecord[pages]("{Pages...,}")
+)
+
+func extractUpdateManual(r io.Reader) (Rules, []Update, error) {
+	var (
+		rules   = Rules{}
+		updates = []Update{}
+	)
+
+	sections, err := reader.NewInput(r).Sections()
+	if err != nil {
+		return rules, updates, err
+	}
+	if len(sections) > 2 {
+		return rules, updates, reader.Errorf(sections[2].Start, 0, "expected page ordering rules and updates, found a third section")
+	}
+
+	// extract page ordering rules
+	if len(sections) > 0 {
+		parsed, err := ruleRecord.ParseLines(sections[0].Lines, sections[0].Start)
+		if err != nil {
+			return rules, updates, err
+		}
+		for _, r := range parsed {
+			rules[strconv.Itoa(r.Before)+"|"+strconv.Itoa(r.After)] = true
+		}
+	}
+
+	// extract pages to produce in each update
+	if len(sections) > 1 {
+		parsed, err := pagesRecord.ParseLines(sections[1].Lines, sections[1].Start)
+		if err != nil {
+			return rules, updates, err
+		}
+		for _, p := range parsed {
+			page := make([]string, len(p.Pages))
+			for i, number := range p.Pages {
+				page[i] = strconv.Itoa(number)
+			}
+			updates = append(updates, Update{page: page})
+		}
+	}
+	return rules, updates, nil
+}
+
+func (r Rules) validUpdate(update Update) bool {
+	page := update.page
+	for i := 0; i < (len(page) - 1); i++ {
+		log.Debug("Check valid page", log.Any("page", page[i:]), log.Int("index", i))
+
+		// Check rules loop
+		// Code logic edge case: if len of page is 2 then check rules loop condition will be false i+1 < len(page[i:])
+		for j := i + 1; j < len(page); j++ {
+			key := page[i] + "|" + page[j]
+			if _, ok := r[key]; !ok {
+				log.Debug("Rule doesn't exist", log.String("key", key))
+				return false
+			}
+		}
+	}
+	log.Debug("Page exist", log.Any("page", page))
+	return true
+}
+
+func (r Rules) updateOrdering(updates []Update) []Update {
+	validUpates := []Update{}
+	for _, update := range updates {
+		log.Debug("Check valid Update", log.Any("page", update.page))
+
+		if r.validUpdate(update) {
+			log.Debug("update is valid", log.Any("page", update.page))
+			validUpates = append(validUpates, update)
+		}
+	}
+	return validUpates
+}
+
+func (r Rules) patchPage(page []string) bool {
+	for i := 0; i < (len(page) - 1); i++ {
+		log.Debug("Check if patch required", log.Any("page", page[i:]), log.Int("index", i))
+		// Check rules loop
+		// Code logic edge case: if len of page is 2 then check rules loop condition will be false i+1 < len(page[i:])
+		for j := i + 1; j < len(page); j++ {
+			key := page[i] + "|" + page[j]
+
+			log.Debug("Check key patch required",
+				log.Any("page[i]", page[i]),
+				log.Any("page[j]", page[j]),
+				log.String("key", key),
+				log.Int("index", i),
+			)
+			if _, ok := r[key]; !ok {
+				// Swap key value
+				page[i], page[j] = page[j], page[i]
+				log.Debug("Swap keyed", log.String("key", key), log.Any("Page", page))
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (r Rules) fixUpdate(update *Update) {
+	var (
+		page    = update.page
+		patched = false
+	)
+	for !patched {
+		log.Debug("Run update patch page", log.Any("page", page))
+		if r.patchPage(page) {
+			update.page = page
+			log.Debug("Patched complete", log.Any("Page", page))
+			patched = true
+		}
+	}
+	return
+}
+
+func (r Rules) fixIncorrectlyUpdates(updates []Update) []Update {
+	for _, update := range updates {
+		r.fixUpdate(&update)
+	}
+	return updates
+}
+
+func sumUpdates(updates []Update) (int, error) {
+	sum := 0
+	for i := 0; i < len(updates); i++ {
+		middleIndex := len(updates[i].page) / Two
+		middle := updates[i].page[middleIndex]
+
+		num, err := strconv.Atoi(middle)
+		if err != nil {
+			log.Error("Failed to convert middle page number to int",
+				log.String("error", err.Error()),
+				log.String("middle", middle),
+				log.Any("page", updates[i].page),
+			)
+			return sum, err
+		}
+		sum += num
+	}
+
+	return sum, nil
+}
+
+func FilterUpdate(updates []Update, remove []Update) []Update {
+	filtered := []Update{}
+	for _, update := range updates {
+		add := true
+		for _, filter := range remove {
+			if reflect.DeepEqual(update, filter) {
+				add = false
+			}
+		}
+		if add {
+			filtered = append(filtered, update)
+		}
+	}
+	return filtered
+}
+
+func init() {
+	registry.Register(registry.Entry{
+		Year:  2024,
+		Day:   5,
+		Title: "Print Queue",
+		Parts: 2,
+		Tags:  []string{"sorting", "graph"},
+		New:   NewSolver,
+	})
+}
+
+// Solver solves day 5 Print Queue
+type Solver struct {
+	rules   Rules
+	updates []Update
+}
+
+// NewSolver returns a new day 5 solver
+func NewSolver() solver.Solver {
+	return &Solver{}
+}
+
+// Parse extracts the page ordering rules and updates
+func (s *Solver) Parse(r io.Reader) error {
+	rules, updates, err := extractUpdateManual(r)
+	if err != nil {
+		return err
+	}
+	s.rules, s.updates = rules, updates
+	return nil
+}
+
+// Part1 sum of middle pages of the correctly-ordered updates
+func (s *Solver) Part1(_ context.Context) (solver.Answer, error) {
+	log.Info("Start Part 1")
+	updateOrdering := s.rules.updateOrdering(s.updates)
+	sum, err := sumUpdates(updateOrdering)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	log.Info("Done Part 1", log.Int("sum", sum))
+	return solver.Int(sum), nil
+}
+
+// Part2 sum of middle pages of the incorrectly-ordered updates after sorting them
+func (s *Solver) Part2(_ context.Context) (solver.Answer, error) {
+	log.Info("Start Part 2")
+	updateOrdering := s.rules.updateOrdering(s.updates)
+	incorrectlyUpdates := []Update{}
+	for _, update := range FilterUpdate(s.updates, updateOrdering) {
+		// Patching is done in place, copy to keep the parsed updates intact
+		incorrectlyUpdates = append(incorrectlyUpdates, update.Copy())
+	}
+
+	fixedUpdateOrdering := s.rules.fixIncorrectlyUpdates(incorrectlyUpdates)
+	sum, err := sumUpdates(fixedUpdateOrdering)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	log.Info("Done Part 2", log.Int("sum", sum))
+	return solver.Int(sum), nil
+}
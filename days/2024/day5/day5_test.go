@@ -0,0 +1,18 @@
+package day5_test
+
+import (
+	"testing"
+
+	_ "aoc2024/days/2024/day5" // Register 2024 day 5
+	"aoc2024/internal/daytest"
+)
+
+// TestExamples tests the 2024 day 5 solver against the examples in testdata
+func TestExamples(t *testing.T) {
+	daytest.Run(t, 2024, 5)
+}
+
+// TestInput tests the 2024 day 5 solver against the real puzzle input, see daytest.InputsEnv
+func TestInput(t *testing.T) {
+	daytest.RunInput(t, 2024, 5)
+}
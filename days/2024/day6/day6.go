@@ -1,11 +1,15 @@
 package day6
 
 import (
-	"bufio"
+	"context"
 	"fmt"
-	"os"
+	"io"
+	"strings"
 
 	"aoc2024/pkg/log"
+	"aoc2024/pkg/reader"
+	"aoc2024/pkg/registry"
+	"aoc2024/pkg/solver"
 )
 
 type Directions int
@@ -32,28 +36,38 @@ var directions = map[Directions]struct{ Dx, Dy int }{
 
 type Lab [][]byte
 
-func (l Lab) PrintLab(guard *Guard) {
-	fmt.Printf("\n")
+// In reports whether position x, y is inside the lab, labs don't have to be square
+func (l Lab) In(x, y int) bool {
+	return reader.Grid(l).In(x, y)
+}
+
+// Render draws the lab with the guard facing its direction
+func (l Lab) Render(guard *Guard) string {
+	builder := strings.Builder{}
 	for y := 0; y < len(l); y++ {
-		for x := 0; x < len(l); x++ {
+		for x := 0; x < len(l[y]); x++ {
 			if guard.Y == y && guard.X == x {
 				switch guard.Dir {
 				case Up:
-					fmt.Printf("^")
+					builder.WriteString("^")
 				case Down:
-					fmt.Printf("⌄")
+					builder.WriteString("⌄")
 				case Left:
-					fmt.Printf("<")
+					builder.WriteString("<")
 				case Right:
-					fmt.Printf(">")
+					builder.WriteString(">")
 				}
 			} else {
-				fmt.Printf("%s", string(l[y][x]))
+				builder.WriteByte(l[y][x])
 			}
 		}
-		fmt.Printf("\n")
+		builder.WriteString("\n")
 	}
-	fmt.Printf("\n")
+	return builder.String()
+}
+
+func (l Lab) PrintLab(guard *Guard) {
+	fmt.Printf("\n%s\n", l.Render(guard))
 }
 
 func (l Lab) Count(char byte) int {
@@ -129,10 +143,7 @@ sim:
 	for {
 		dir := directions[guard.Dir]
 		nx, ny := guard.X+dir.Dx, guard.Y+dir.Dy
-		if ny < 0 || ny >= len(patrolMap[0]) ||
-			guard.Y < 0 || guard.Y >= len(patrolMap) ||
-			guard.X < 0 || guard.X >= len(patrolMap[0]) ||
-			nx < 0 || nx >= len(patrolMap[0]) {
+		if !patrolMap.In(guard.X, guard.Y) || !patrolMap.In(nx, ny) {
 			break sim
 		}
 
@@ -175,12 +186,19 @@ sim:
 	return patrolMap, looped
 }
 
-func GuardLoopSimulation(guard *Guard, lab, patrolMap Lab) int {
-	guardLoopedCount := 0
+// GuardLoopSimulation counts the obstructions on the guard patrol that gets the guard stuck in a loop.
+// It stops with the context error once ctx is done.
+func GuardLoopSimulation(ctx context.Context, guard *Guard, lab, patrolMap Lab) (int, error) {
+	var (
+		guardLoopedCount = 0
+		simulations      = 0
+		// Every marked position except the guard starting position
+		candidates = patrolMap.Count(Marked) - 1
+	)
 
 	// For every X mark try simlutate with a O marker to check if loop, expcept for guard current position.
 	for y := 0; y < len(patrolMap); y++ {
-		for x := 0; x < len(patrolMap); x++ {
+		for x := 0; x < len(patrolMap[y]); x++ {
 			if guard.Y == y && guard.X == x {
 				log.Debug("Guard Position encoutered",
 					log.Int("Y", y),
@@ -191,6 +209,10 @@ func GuardLoopSimulation(guard *Guard, lab, patrolMap Lab) int {
 				continue
 			}
 			if patrolMap[y][x] == Marked {
+				if err := ctx.Err(); err != nil {
+					return guardLoopedCount, err
+				}
+
 				// Make new map with ObstructionLoop 'O'
 				simLab := lab.Copy()
 				simLab[y][x] = ObstructionLoop
@@ -202,8 +224,9 @@ func GuardLoopSimulation(guard *Guard, lab, patrolMap Lab) int {
 					log.Int("O.y", y),
 					log.Int("O.x", x),
 				)
-				simPatrolMap, looped := simGuard.SimulateGuardPatrol(simLab)
-				simPatrolMap.PrintLab(simGuard)
+				_, looped := simGuard.SimulateGuardPatrol(simLab)
+				simulations++
+				solver.ReportProgress(ctx, simulations, candidates)
 				if looped {
 					guardLoopedCount++
 					log.Info("Successfully looped guard",
@@ -224,60 +247,100 @@ func GuardLoopSimulation(guard *Guard, lab, patrolMap Lab) int {
 		}
 	}
 
-	return guardLoopedCount
+	return guardLoopedCount, nil
 }
 
-func extractLaboratory(filename string) (Lab, *Guard) {
-	file, err := os.Open(filename)
-	if err != nil {
-		log.Fatal("Failed to open file", log.String("filename", filename), log.String("error", err.Error()))
-	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	scanner.Split(bufio.ScanLines)
-
+func extractLaboratory(r io.Reader) (Lab, *Guard, error) {
 	var (
-		lab   = Lab{}
 		guard = &Guard{Dir: Up}
+		found = false
 	)
-	y := 0
-	for scanner.Scan() {
-		line := []byte(scanner.Text())
 
+	grid, err := reader.NewInput(r).Grid()
+	if err != nil {
+		return Lab{}, guard, err
+	}
+	lab := Lab(grid)
+
+	for y, line := range lab {
 		for x := 0; x < len(line); x++ {
-			if line[x] == GuardUp {
+			switch line[x] {
+			case Empty, Obstruction:
+			case GuardUp:
+				if found {
+					return lab, guard, reader.Errorf(y+1, x+1, "second guard found, first at line %d column %d", guard.Y+1, guard.X+1)
+				}
 				log.Debug("Guard Position found in map", log.Int("Y", y), log.Int("X", x))
 				guard.X = x
 				guard.Y = y
 				line[x] = Marked
+				found = true
+			default:
+				return lab, guard, reader.Errorf(y+1, x+1, "unexpected character %q", line[x])
 			}
 		}
-		lab = append(lab, line)
-		y++
 	}
-	return lab, guard
+	if !found {
+		return lab, guard, fmt.Errorf("no guard %q found in laboratory", GuardUp)
+	}
+	return lab, guard, nil
 }
 
-func AdventSolveDay6(filename string) {
-	log.Info("Day 6 Extract Laboratory", log.String("filename", filename))
-	lab, guard := extractLaboratory(filename)
-	lab.PrintLab(guard)
+func init() {
+	registry.Register(registry.Entry{
+		Year:  2024,
+		Day:   6,
+		Title: "Guard Gallivant",
+		Parts: 2,
+		Tags:  []string{"grid", "simulation"},
+		New:   NewSolver,
+	})
+}
 
-	startGuard := &Guard{Dir: guard.Dir, X: guard.X, Y: guard.Y}
+// Solver solves day 6 Guard Gallivant
+type Solver struct {
+	lab   Lab
+	guard *Guard
+}
 
-	log.Info("Start Part 1", log.String("filename", filename))
-	patrolMap, _ := guard.SimulateGuardPatrol(lab)
-	patrolMap.PrintLab(guard)
+// NewSolver returns a new day 6 solver
+func NewSolver() solver.Solver {
+	return &Solver{}
+}
 
-	distinctPositions := patrolMap.Count(Marked)
+// Parse extracts the laboratory and the guard starting position
+func (s *Solver) Parse(r io.Reader) error {
+	log.Info("Day 6 Extract Laboratory")
+	lab, guard, err := extractLaboratory(r)
+	if err != nil {
+		return err
+	}
+	s.lab, s.guard = lab, guard
+	log.Debug("Laboratory extracted", log.String("lab", s.lab.Render(s.guard)))
+	return nil
+}
 
-	fmt.Println("Distinct positions:", distinctPositions)
-	log.Info("Done Part 1", log.String("filename", filename))
+// Part1 distinct positions visited by the guard
+func (s *Solver) Part1(_ context.Context) (solver.Answer, error) {
+	log.Info("Start Part 1")
+	guard := s.guard.Copy()
+	patrolMap, _ := guard.SimulateGuardPatrol(s.lab)
+	log.Debug("Guard patrol done", log.String("lab", patrolMap.Render(guard)))
 
-	log.Info("Start Part 2", log.String("filename", filename))
-	guardLoopedCount := GuardLoopSimulation(startGuard, lab, patrolMap)
+	distinctPositions := patrolMap.Count(Marked)
+	log.Info("Done Part 1", log.Int("distinct-positions", distinctPositions))
+	return solver.Int(distinctPositions), nil
+}
 
-	fmt.Println("Guard Stuck in Looped:", guardLoopedCount)
-	log.Info("Done Part 2", log.String("filename", filename))
+// Part2 positions of an obstruction that would get the guard stuck in a loop
+func (s *Solver) Part2(ctx context.Context) (solver.Answer, error) {
+	log.Info("Start Part 2")
+	patrolMap, _ := s.guard.Copy().SimulateGuardPatrol(s.lab)
+	guardLoopedCount, err := GuardLoopSimulation(ctx, s.guard.Copy(), s.lab, patrolMap)
+	if err != nil {
+		log.Warn("Part 2 stopped", log.String("error", err.Error()), log.Int("looped", guardLoopedCount))
+		return solver.Answer{}, err
+	}
+	log.Info("Done Part 2", log.Int("looped", guardLoopedCount))
+	return solver.Int(guardLoopedCount), nil
 }
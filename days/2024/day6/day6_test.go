@@ -0,0 +1,49 @@
+package day6_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	_ "aoc2024/days/2024/day6" // Register 2024 day 6
+	"aoc2024/internal/daytest"
+	"aoc2024/pkg/registry"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExamples tests the 2024 day 6 solver against the examples in testdata
+func TestExamples(t *testing.T) {
+	daytest.Run(t, 2024, 6)
+}
+
+// TestInput tests the 2024 day 6 solver against the real puzzle input, see daytest.InputsEnv
+func TestInput(t *testing.T) {
+	daytest.RunInput(t, 2024, 6)
+}
+
+// TestLabNotSquare tests labs wider and taller than square, the only loop of the wide lab is past its height
+func TestLabNotSquare(t *testing.T) {
+	entry, ok := registry.Lookup(2024, 6)
+	require.True(t, ok)
+
+	for name, scenario := range map[string]struct {
+		lab      string
+		expected []string
+	}{
+		"Wide": {lab: "......#.\n.....#^.\n....#.#.\n", expected: []string{"2", "1"}},
+		"Tall": {lab: "...\n...\n...\n.^.\n...\n", expected: []string{"4", "0"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := entry.New()
+			require.NoError(t, s.Parse(strings.NewReader(scenario.lab)))
+
+			part1, err := s.Part1(context.Background())
+			require.NoError(t, err)
+			part2, err := s.Part2(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, scenario.expected, []string{part1.String(), part2.String()})
+		})
+	}
+}
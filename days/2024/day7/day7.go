@@ -0,0 +1,195 @@
+package day7
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"aoc2024/pkg/log"
+	"aoc2024/pkg/reader"
+	"aoc2024/pkg/registry"
+	"aoc2024/pkg/solver"
+)
+
+type Operator string
+
+const (
+	Addition       Operator = "+"
+	Multiplication Operator = "*"
+	Concatenation  Operator = "||"
+)
+
+func (o Operator) Eval(a, b int) int {
+	switch o {
+	case Addition:
+		return a + b
+	case Multiplication:
+		return a * b
+	case Concatenation:
+		con, err := strconv.Atoi(fmt.Sprintf("%d%d", a, b))
+		if err != nil {
+			panic(err.Error())
+		}
+		return con
+	}
+	panic(fmt.Sprintf("Non supported operator %v", o))
+}
+
+func CartesianProductOperators(operators []Operator, repeat int) [][]Operator {
+	if repeat == 0 {
+		return [][]Operator{}
+	}
+
+	if repeat == 1 {
+		result := [][]Operator{}
+		for _, op := range operators {
+			result = append(result, []Operator{op})
+		}
+		return result
+	}
+
+	// Initialize result with single character combinations
+	result := make([][]Operator, len(operators))
+	for i, op := range operators {
+		result[i] = []Operator{op}
+	}
+
+	// Iteratively build combinations of the desired repeat
+	for i := 1; i < repeat; i++ {
+		var newResult [][]Operator
+		for _, combination := range result {
+			for _, op := range operators {
+				newCombination := append([]Operator{}, combination...)
+				newCombination = append(newCombination, op)
+				newResult = append(newResult, newCombination)
+			}
+		}
+		result = newResult
+	}
+
+	return result
+}
+
+type CalibrationEquation struct {
+	Test     int
+	Equation []int
+}
+
+// Evaluate reports whether any combination of operators makes the equation equal its test value.
+// It stops with the context error once ctx is done.
+func (eq *CalibrationEquation) Evaluate(ctx context.Context, operators []Operator) (bool, error) {
+	// Create the Cartesian product of the set of operators {+, *}
+	product := CartesianProductOperators(operators, len(eq.Equation)-1)
+	log.Debug("Cartesian Product Operators",
+		log.Any("product", product),
+		log.Any("Equation", eq.Equation),
+	)
+
+	for _, ops := range product {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		log.Debug("New Operators Equation",
+			log.Any("Equation", eq.Equation),
+			log.Any("operators", ops),
+			log.Int("test", eq.Test),
+		)
+		// Evaluate equation
+		total := eq.Equation[0]
+		for i, op := range ops {
+			res := op.Eval(total, eq.Equation[i+1])
+			total = res
+		}
+
+		// Check if equation matches test
+		if total == eq.Test {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func calibrationEquationsPatcher(ctx context.Context, calibrationEquations []CalibrationEquation, operators []Operator) (int, error) {
+	total := 0
+	for i, calibrationEquation := range calibrationEquations {
+		valid, err := calibrationEquation.Evaluate(ctx, operators)
+		if err != nil {
+			return total, err
+		}
+		if valid {
+			total += calibrationEquation.Test
+		}
+		solver.ReportProgress(ctx, i+1, len(calibrationEquations))
+	}
+	return total, nil
+}
+
+var calibrationEquation = reader.MustRecord[CalibrationEquation]("{Test}: {Equation...}")
+
+func extractCalibrationEquations(r io.Reader) ([]CalibrationEquation, error) {
+	lines, err := reader.NewInput(r).Lines()
+	if err != nil {
+		return []CalibrationEquation{}, err
+	}
+
+	calibrationEquations, err := calibrationEquation.ParseLines(lines, 1)
+	if err != nil {
+		return []CalibrationEquation{}, err
+	}
+	log.Debug("Calibration equations extracted", log.Int("equations", len(calibrationEquations)))
+	return calibrationEquations, nil
+}
+
+func init() {
+	registry.Register(registry.Entry{
+		Year:  2024,
+		Day:   7,
+		Title: "Bridge Repair",
+		Parts: 2,
+		Tags:  []string{"brute-force"},
+		New:   NewSolver,
+	})
+}
+
+// Solver solves day 7 Bridge Repair
+type Solver struct {
+	calibrationEquations []CalibrationEquation
+}
+
+// NewSolver returns a new day 7 solver
+func NewSolver() solver.Solver {
+	return &Solver{}
+}
+
+// Parse extracts the calibration equations
+func (s *Solver) Parse(r io.Reader) error {
+	calibrationEquations, err := extractCalibrationEquations(r)
+	if err != nil {
+		return err
+	}
+	s.calibrationEquations = calibrationEquations
+	return nil
+}
+
+// Part1 total calibration result using addition and multiplication
+func (s *Solver) Part1(ctx context.Context) (solver.Answer, error) {
+	log.Info("Start Part 1")
+	calibrationTotal, err := calibrationEquationsPatcher(ctx, s.calibrationEquations, []Operator{Addition, Multiplication})
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	log.Info("Done Part 1", log.Int("total", calibrationTotal))
+	return solver.Int(calibrationTotal), nil
+}
+
+// Part2 total calibration result using addition, multiplication and concatenation
+func (s *Solver) Part2(ctx context.Context) (solver.Answer, error) {
+	log.Info("Start Part 2")
+	calibrationTotal, err := calibrationEquationsPatcher(ctx, s.calibrationEquations, []Operator{Addition, Multiplication, Concatenation})
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	log.Info("Done Part 2", log.Int("total", calibrationTotal))
+	return solver.Int(calibrationTotal), nil
+}
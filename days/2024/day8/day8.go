@@ -1,12 +1,15 @@
 package day8
 
 import (
-	"bufio"
+	"context"
 	"fmt"
-	"os"
+	"io"
 	"strings"
 
 	"aoc2024/pkg/log"
+	"aoc2024/pkg/reader"
+	"aoc2024/pkg/registry"
+	"aoc2024/pkg/solver"
 )
 
 const dot = '.'
@@ -163,52 +166,69 @@ func ResonantCollinearityHarmonics(frequencyNodes FrequencyNodeMap, mapBoarder M
 	return antiNodes
 }
 
-func extractFile(filename string) (FrequencyNodeMap, MapBoarder) {
-	file, err := os.Open(filename)
+func extractFile(r io.Reader) (FrequencyNodeMap, MapBoarder, error) {
+	frequencyNodes := FrequencyNodeMap{}
+	grid, err := reader.NewInput(r).Grid()
 	if err != nil {
-		log.Fatal("Failed to open file",
-			log.String("filename", filename),
-			log.String("error", err.Error()),
-		)
+		return frequencyNodes, MapBoarder{}, err
 	}
-	defer file.Close()
-
-	lines := bufio.NewScanner(file)
-	lines.Split(bufio.ScanLines)
 
-	frequencyNodes := FrequencyNodeMap{}
-	xLength := 0
-	y := 0
-	for lines.Scan() {
-		line := lines.Text()
-		xLength = len(line)
+	for y, line := range grid {
 		for x := 0; x < len(line); x++ {
 			if line[x] != dot {
 				freq := rune(line[x])
 				frequencyNodes[freq] = append(frequencyNodes[freq], Node{X: x, Y: y})
 			}
 		}
-		y++
 	}
-	return frequencyNodes, MapBoarder{X: xLength, Y: y}
+	return frequencyNodes, MapBoarder{X: grid.Width(), Y: grid.Height()}, nil
 }
 
-func AdventSolveDay8(filename string) {
-	log.Info("Start Part 1", log.String("filename", filename))
-	frequencyNodes, mapBoarder := extractFile(filename)
-	frequencyNodes.Print()
+func init() {
+	registry.Register(registry.Entry{
+		Year:  2024,
+		Day:   8,
+		Title: "Resonant Collinearity",
+		Parts: 2,
+		Tags:  []string{"grid", "geometry"},
+		New:   NewSolver,
+	})
+}
 
-	antiNodes := ResonantCollinearity(frequencyNodes, mapBoarder)
-	antiNodes.Print()
-	fmt.Println("total unique locations:", antiNodes.Unqiue())
+// Solver solves day 8 Resonant Collinearity
+type Solver struct {
+	frequencyNodes FrequencyNodeMap
+	mapBoarder     MapBoarder
+}
 
-	log.Info("Done Part 1", log.String("filename", filename), log.Int("locations", antiNodes.Unqiue()))
+// NewSolver returns a new day 8 solver
+func NewSolver() solver.Solver {
+	return &Solver{}
+}
 
-	log.Info("Start Part 2", log.String("filename", filename))
+// Parse extracts the antennas frequencies and the map boarder
+func (s *Solver) Parse(r io.Reader) error {
+	frequencyNodes, mapBoarder, err := extractFile(r)
+	if err != nil {
+		return err
+	}
+	s.frequencyNodes, s.mapBoarder = frequencyNodes, mapBoarder
+	log.Debug("Antennas extracted", log.Any("frequency-nodes", s.frequencyNodes), log.Any("boarder", s.mapBoarder))
+	return nil
+}
 
-	antiNodes = ResonantCollinearityHarmonics(frequencyNodes, mapBoarder)
-	antiNodes.Print()
-	fmt.Println("total unique locations:", antiNodes.Unqiue())
+// Part1 unique locations containing an antinode
+func (s *Solver) Part1(_ context.Context) (solver.Answer, error) {
+	log.Info("Start Part 1")
+	antiNodes := ResonantCollinearity(s.frequencyNodes, s.mapBoarder)
+	log.Info("Done Part 1", log.Int("locations", antiNodes.Unqiue()))
+	return solver.Int(antiNodes.Unqiue()), nil
+}
 
-	log.Info("Done Part 2", log.String("filename", filename), log.Int("locations", antiNodes.Unqiue()))
+// Part2 unique locations containing an antinode with resonant harmonics
+func (s *Solver) Part2(_ context.Context) (solver.Answer, error) {
+	log.Info("Start Part 2")
+	antiNodes := ResonantCollinearityHarmonics(s.frequencyNodes, s.mapBoarder)
+	log.Info("Done Part 2", log.Int("locations", antiNodes.Unqiue()))
+	return solver.Int(antiNodes.Unqiue()), nil
 }
@@ -0,0 +1,13 @@
+// Package days registers the solver of every solved day, import it for its side effects.
+package days
+
+import (
+	_ "aoc2024/days/2024/day1" // 2024 Day 1 Historian Hysteria
+	_ "aoc2024/days/2024/day2" // 2024 Day 2 Red-Nosed Reports
+	_ "aoc2024/days/2024/day3" // 2024 Day 3 Mull It Over
+	_ "aoc2024/days/2024/day4" // 2024 Day 4 Ceres Search
+	_ "aoc2024/days/2024/day5" // 2024 Day 5 Print Queue
+	_ "aoc2024/days/2024/day6" // 2024 Day 6 Guard Gallivant
+	_ "aoc2024/days/2024/day7" // 2024 Day 7 Bridge Repair
+	_ "aoc2024/days/2024/day8" // 2024 Day 8 Resonant Collinearity
+)
@@ -0,0 +1,42 @@
+package days_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	_ "aoc2024/days" // Register every solved day
+	"aoc2024/internal/daytest"
+	"aoc2024/pkg/registry"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExamples tests that every registered day has examples with expected answers
+func TestExamples(t *testing.T) {
+	for _, entry := range registry.Entries() {
+		t.Run(fmt.Sprintf("%d/day%d", entry.Year, entry.Day), func(t *testing.T) {
+			dir := filepath.Join(strconv.Itoa(entry.Year), fmt.Sprintf("day%d", entry.Day), daytest.TestData)
+			files, answers, err := daytest.Examples(dir)
+			require.NoError(t, err)
+			require.NotEmpty(t, files, "%d day %d has no %s examples", entry.Year, entry.Day, filepath.Join(dir, daytest.ExamplePattern))
+
+			for _, file := range files {
+				info, err := os.Stat(file)
+				require.NoError(t, err)
+				assert.NotZero(t, info.Size(), "example %s is empty", file)
+
+				expected := 0
+				for _, part := range entry.PartNumbers() {
+					if answers[filepath.Base(file)][part] != "" {
+						expected++
+					}
+				}
+				assert.NotZero(t, expected, "example %s has no expected answers in %s", file, daytest.AnswersFile)
+			}
+		})
+	}
+}
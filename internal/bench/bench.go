@@ -0,0 +1,201 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"aoc2024/internal/run"
+	"aoc2024/pkg/reader"
+	"aoc2024/pkg/registry"
+	"aoc2024/pkg/solver"
+)
+
+const (
+	percentile95 = 0.95
+	tablePadding = 2
+)
+
+// Stats timing and allocation statistics of a benchmarked stage
+type Stats struct {
+	Day         int
+	Part        int // Part is the benchmarked part or solver.PartParse for the parse stage
+	Runs        int
+	Min         time.Duration
+	Median      time.Duration
+	P95         time.Duration
+	Max         time.Duration
+	AllocsPerOp uint64
+	BytesPerOp  uint64
+}
+
+// Stage returns the name of the benchmarked stage
+func (s Stats) Stage() string {
+	return solver.Result{Part: s.Part}.Stage()
+}
+
+// sample measurements of a single stage
+type sample struct {
+	durations []time.Duration
+	allocs    uint64
+	bytes     uint64
+}
+
+// measure runs fn once and records its duration and allocations
+func (s *sample) measure(fn func() error) error {
+	var before, after runtime.MemStats
+
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	err := fn()
+	duration := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	s.durations = append(s.durations, duration)
+	s.allocs += after.Mallocs - before.Mallocs
+	s.bytes += after.TotalAlloc - before.TotalAlloc
+	return err
+}
+
+func (s *sample) stats(day, part int) Stats {
+	sorted := append([]time.Duration{}, s.durations...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	runs := len(sorted)
+	return Stats{
+		Day:         day,
+		Part:        part,
+		Runs:        runs,
+		Min:         sorted[0],
+		Median:      sorted[runs/2],
+		P95:         sorted[percentileIndex(runs, percentile95)],
+		Max:         sorted[runs-1],
+		AllocsPerOp: s.allocs / uint64(runs),
+		BytesPerOp:  s.bytes / uint64(runs),
+	}
+}
+
+// percentileIndex nearest-rank index of percentile p in n sorted samples
+func percentileIndex(n int, p float64) int {
+	rank := int(math.Ceil(p*float64(n))) - 1
+	if rank < 0 {
+		return 0
+	}
+	if rank >= n {
+		return n - 1
+	}
+	return rank
+}
+
+// readInput returns the content of file and the function releasing it, a memory mapped file is not copied
+func readInput(file string, opts ...reader.OptFunc) ([]byte, func() error, error) {
+	input, err := reader.Open(file, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if content := input.Bytes(); content != nil {
+		return content, input.Close, nil
+	}
+	defer input.Close()
+
+	content, err := io.ReadAll(input)
+	return content, func() error { return nil }, err
+}
+
+// Day benchmarks the selected part of day in year with the puzzle input file n times, opts set how the input is read.
+// The input is read once up front so the parse stage doesn't measure disk reads.
+// Every run parses the input into a fresh solver and solves the parts, the first error aborts the benchmark.
+func Day(ctx context.Context, year, day int, file string, part int, n int, opts ...reader.OptFunc) ([]Stats, error) {
+	entry, ok := registry.Lookup(year, day)
+	if !ok {
+		return nil, fmt.Errorf("unrecognized or not solved day %v in %d", day, year)
+	}
+	if n < 1 {
+		return nil, fmt.Errorf("number of runs must be at least 1, got %d", n)
+	}
+
+	content, release, err := readInput(file, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("day %d input: %w", day, err)
+	}
+	defer release()
+
+	parts, err := run.SelectParts(entry, part)
+	if err != nil {
+		return nil, err
+	}
+	samples := map[int]*sample{solver.PartParse: {}}
+	for _, part := range parts {
+		samples[part] = &sample{}
+	}
+
+	pprof.Do(ctx, pprof.Labels("year", strconv.Itoa(year), "day", strconv.Itoa(day)), func(ctx context.Context) {
+		err = measure(ctx, entry, content, parts, samples, n, opts)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("day %d %w", day, err)
+	}
+
+	stats := []Stats{samples[solver.PartParse].stats(day, solver.PartParse)}
+	for _, part := range parts {
+		stats = append(stats, samples[part].stats(day, part))
+	}
+	return stats, nil
+}
+
+// PrintStats writes a table of the benchmark statistics
+func PrintStats(w io.Writer, stats []Stats) error {
+	table := tabwriter.NewWriter(w, 0, 0, tablePadding, ' ', 0)
+	fmt.Fprintln(table, "DAY\tPART\tRUNS\tMIN\tMEDIAN\tP95\tMAX\tALLOCS/OP\tB/OP")
+
+	for _, s := range stats {
+		fmt.Fprintf(table, "%d\t%s\t%d\t%s\t%s\t%s\t%s\t%d\t%d\n",
+			s.Day, s.Stage(), s.Runs, s.Min, s.Median, s.P95, s.Max, s.AllocsPerOp, s.BytesPerOp,
+		)
+	}
+
+	return table.Flush()
+}
+
+// measure solves the parts of content n times with a new solver of entry and records their samples
+func measure(ctx context.Context, entry registry.Entry, content []byte, parts []int, samples map[int]*sample, n int, opts []reader.OptFunc) error {
+	for i := 0; i < n; i++ {
+		// parts don't have to poll ctx, stop between runs on Ctrl-C or timeout
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("run %d: %w", i+1, err)
+		}
+		s := entry.New()
+
+		var err error
+		solver.Labeled(ctx, solver.PartParse, func(context.Context) {
+			err = samples[solver.PartParse].measure(func() error {
+				return s.Parse(reader.FromBytes(content, opts...))
+			})
+		})
+		if err != nil {
+			return fmt.Errorf("parse: %w", err)
+		}
+
+		for _, part := range parts {
+			solver.Labeled(ctx, part, func(ctx context.Context) {
+				err = samples[part].measure(func() error {
+					_, err := solver.PartFunc(s, part)(ctx)
+					return err
+				})
+			})
+			if err != nil {
+				return fmt.Errorf("part %d: %w", part, err)
+			}
+		}
+	}
+	return nil
+}
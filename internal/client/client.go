@@ -0,0 +1,141 @@
+// Package client talks to the Advent of Code website, it fetches puzzle inputs and submits answers
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// DefaultBaseURL is the Advent of Code website
+	DefaultBaseURL = "https://adventofcode.com"
+	// UserAgent identifies the tool to the website as its maintainers ask for
+	UserAgent = "aoc2024 (+https://github.com/meDracula/AdventOfCode2024)"
+
+	dirPermissions  = 0o750
+	filePermissions = 0o600
+
+	// errorBodyLimit is the part of an error response kept in the error
+	errorBodyLimit = 200
+)
+
+// ErrNoSession is returned when a request needs the session token but none is configured
+var ErrNoSession = errors.New("no session token, set AOC_SESSION or session in .aoc.yaml")
+
+// Client of the Advent of Code website authenticated by the session cookie of a logged in user
+type Client struct {
+	BaseURL  string
+	Session  string
+	HTTP     *http.Client
+	Throttle Throttle
+}
+
+// New returns a client of the website at baseURL, DefaultBaseURL when empty
+func New(baseURL, session string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		Session: session,
+		HTTP:    http.DefaultClient,
+	}
+}
+
+// Input downloads the puzzle input of day in year
+func (c *Client) Input(ctx context.Context, year, day int) ([]byte, error) {
+	resp, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/%d/day/%d/input", year, day), "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError(resp, body)
+	}
+	return body, nil
+}
+
+// FetchInput downloads the puzzle input of day in year into file unless file already exists,
+// cached inputs are never downloaded again. It reports whether the input was downloaded.
+func (c *Client) FetchInput(ctx context.Context, year, day int, file string) (bool, error) {
+	if _, err := os.Stat(file); err == nil {
+		return false, nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return false, err
+	}
+
+	input, err := c.Input(ctx, year, day)
+	if err != nil {
+		return false, fmt.Errorf("%d day %d: %w", year, day, err)
+	}
+	return true, writeFile(file, input)
+}
+
+// do sends a request to path of the website with the session cookie
+func (c *Client) do(ctx context.Context, method, path, contentType string, body io.Reader) (*http.Response, error) {
+	if c.Session == "" {
+		return nil, ErrNoSession
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", UserAgent)
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return c.HTTP.Do(req)
+}
+
+// statusError returns the error of an unexpected response status with the start of its body
+func statusError(resp *http.Response, body []byte) error {
+	message := strings.TrimSpace(string(body))
+	if len(message) > errorBodyLimit {
+		message = message[:errorBodyLimit] + "..."
+	}
+	if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized {
+		message = "session token rejected, it may have expired: " + message
+	}
+	return fmt.Errorf("%s %s: %s: %s", resp.Request.Method, resp.Request.URL.Path, resp.Status, message)
+}
+
+// writeFile writes content to file readable by the owner only, a partial download never replaces file
+func writeFile(file string, content []byte) error {
+	dir := filepath.Dir(file)
+	if err := os.MkdirAll(dir, dirPermissions); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(file)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(filePermissions); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
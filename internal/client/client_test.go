@@ -0,0 +1,87 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"aoc2024/internal/client"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSite returns a stand-in of the website serving input to requests with session, counting the downloads
+func fakeSite(t *testing.T, session, input string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	downloads := &atomic.Int32{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /2024/day/3/input", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, client.UserAgent, r.UserAgent())
+		cookie, err := r.Cookie("session")
+		if err != nil || cookie.Value != session {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+		downloads.Add(1)
+		_, _ = w.Write([]byte(input))
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, downloads
+}
+
+// TestFetchInput tests for method FetchInput
+func TestFetchInput(t *testing.T) {
+	srv, downloads := fakeSite(t, "cookie", "xmul(2,4)\n")
+	c := client.New(srv.URL, "cookie")
+	file := filepath.Join(t.TempDir(), "inputs", "2024", "day03.txt")
+
+	fetched, err := c.FetchInput(context.Background(), 2024, 3, file)
+	require.NoError(t, err)
+	assert.True(t, fetched)
+
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, "xmul(2,4)\n", string(content))
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	fetched, err = c.FetchInput(context.Background(), 2024, 3, file)
+	require.NoError(t, err)
+	assert.False(t, fetched, "Expected cached input not to be downloaded again")
+	assert.Equal(t, int32(1), downloads.Load())
+}
+
+// TestFetchInputErrors tests that failed downloads leave no input behind
+func TestFetchInputErrors(t *testing.T) {
+	srv, _ := fakeSite(t, "cookie", "xmul(2,4)\n")
+
+	scenarios := map[string]struct {
+		session string
+		day     int
+		err     string
+	}{
+		"no session":      {session: "", day: 3, err: client.ErrNoSession.Error()},
+		"expired session": {session: "expired", day: 3, err: "session token rejected"},
+		"unknown day":     {session: "cookie", day: 4, err: "404 Not Found"},
+	}
+
+	for name, s := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "day.txt")
+			fetched, err := client.New(srv.URL, s.session).FetchInput(context.Background(), 2024, s.day, file)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), s.err)
+			assert.False(t, fetched)
+			assert.NoFileExists(t, file)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Status of a submitted answer
+type Status int
+
+const (
+	Correct Status = iota + 1
+	Wrong
+	TooHigh
+	TooLow
+	RateLimited
+	AlreadySolved
+)
+
+// String returns the status as printed by the CLI
+func (s Status) String() string {
+	switch s {
+	case Correct:
+		return "correct"
+	case Wrong:
+		return "wrong"
+	case TooHigh:
+		return "too high"
+	case TooLow:
+		return "too low"
+	case RateLimited:
+		return "rate limited"
+	case AlreadySolved:
+		return "already solved"
+	}
+	return "unknown"
+}
+
+// Verdict of the website on a submitted answer, Wait is the time to wait before the next answer
+type Verdict struct {
+	Status  Status
+	Wait    time.Duration
+	Message string
+}
+
+var (
+	articleExpr = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagExpr     = regexp.MustCompile(`<[^>]*>`)
+	spaceExpr   = regexp.MustCompile(`\s+`)
+	// leftExpr matches the time left of a rate limited answer e.g. You have 1m 5s left to wait
+	leftExpr = regexp.MustCompile(`You have (?:(\d+)m )?(\d+)s left to wait`)
+	// waitExpr matches the wait after a wrong answer e.g. please wait one minute, please wait 5 minutes
+	waitExpr = regexp.MustCompile(`wait (one|\d+) minutes?`)
+)
+
+// Submit posts answer of part of day in year. An answer within the wait recorded by Throttle is
+// not posted and is RateLimited, the wait of the verdict is recorded for the next answer.
+func (c *Client) Submit(ctx context.Context, year, day, part int, answer string) (Verdict, error) {
+	if wait, err := c.Throttle.Remaining(time.Now()); err != nil || wait > 0 {
+		return Verdict{Status: RateLimited, Wait: wait, Message: "not submitted, the last answer was too recent"}, err
+	}
+
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+	resp, err := c.do(ctx, http.MethodPost, fmt.Sprintf("/%d/day/%d/answer", year, day),
+		"application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		return Verdict{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Verdict{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return Verdict{}, statusError(resp, body)
+	}
+
+	verdict, err := ParseVerdict(string(body))
+	if err != nil {
+		return verdict, err
+	}
+	if verdict.Wait > 0 {
+		err = c.Throttle.Record(time.Now().Add(verdict.Wait))
+	}
+	return verdict, err
+}
+
+// ParseVerdict parses the answer page of the website
+func ParseVerdict(page string) (Verdict, error) {
+	match := articleExpr.FindStringSubmatch(page)
+	if match == nil {
+		return Verdict{}, errors.New("answer page has no article")
+	}
+	message := html.UnescapeString(tagExpr.ReplaceAllString(match[1], ""))
+	verdict := Verdict{Message: strings.TrimSpace(spaceExpr.ReplaceAllString(message, " "))}
+
+	switch {
+	case strings.Contains(message, "That's the right answer"):
+		verdict.Status = Correct
+	case strings.Contains(message, "You gave an answer too recently"):
+		verdict.Status = RateLimited
+	case strings.Contains(message, "Did you already complete it"):
+		verdict.Status = AlreadySolved
+	case strings.Contains(message, "your answer is too high"):
+		verdict.Status = TooHigh
+	case strings.Contains(message, "your answer is too low"):
+		verdict.Status = TooLow
+	case strings.Contains(message, "That's not the right answer"):
+		verdict.Status = Wrong
+	default:
+		return verdict, fmt.Errorf("unknown answer verdict %q", verdict.Message)
+	}
+
+	if left := leftExpr.FindStringSubmatch(message); left != nil {
+		minutes, _ := strconv.Atoi(left[1]) // Only digits, empty without minutes
+		seconds, _ := strconv.Atoi(left[2])
+		verdict.Wait = time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	} else if wait := waitExpr.FindStringSubmatch(message); wait != nil {
+		minutes := 1
+		if wait[1] != "one" {
+			minutes, _ = strconv.Atoi(wait[1]) // Only digits
+		}
+		verdict.Wait = time.Duration(minutes) * time.Minute
+	}
+	return verdict, nil
+}
+
+// Throttle remembers in File until when the website refuses answers, across runs of the CLI.
+// The zero Throttle remembers nothing.
+type Throttle struct {
+	File string
+}
+
+// Remaining returns the time left to wait at now before the next answer
+func (t Throttle) Remaining(now time.Time) (time.Duration, error) {
+	if t.File == "" {
+		return 0, nil
+	}
+	content, err := os.ReadFile(t.File)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	until, err := time.Parse(time.RFC3339, strings.TrimSpace(string(content)))
+	if err != nil {
+		return 0, fmt.Errorf("throttle %s: %w", t.File, err)
+	}
+	return max(until.Sub(now), 0).Round(time.Second), nil
+}
+
+// Record remembers that no answer is accepted before until
+func (t Throttle) Record(until time.Time) error {
+	if t.File == "" {
+		return nil
+	}
+	return writeFile(t.File, []byte(until.Format(time.RFC3339)+"\n"))
+}
@@ -0,0 +1,119 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"aoc2024/internal/client"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// page returns an answer page of the website with article
+func page(article string) string {
+	return "<html><body><main>\n<article><p>" + article + "</p></article>\n</main></body></html>"
+}
+
+// TestParseVerdict tests for function ParseVerdict
+func TestParseVerdict(t *testing.T) {
+	scenarios := map[string]struct {
+		article string
+		status  client.Status
+		wait    time.Duration
+	}{
+		"correct": {
+			article: `That's the right answer!  You are <span class="day-success">one gold star</span> closer to finding the Chief Historian.`,
+			status:  client.Correct,
+		},
+		"wrong": {
+			article: `That's not the right answer.  If you're stuck, make sure you're using the full input data; please wait one minute before trying again.`,
+			status:  client.Wrong,
+			wait:    time.Minute,
+		},
+		"too high": {
+			article: `That's not the right answer; your answer is too high.  Please wait 5 minutes before trying again.`,
+			status:  client.TooHigh,
+			wait:    5 * time.Minute,
+		},
+		"too low": {
+			article: `That's not the right answer; your answer is too low.  please wait one minute before trying again.`,
+			status:  client.TooLow,
+			wait:    time.Minute,
+		},
+		"rate limited": {
+			article: `You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 1m 5s left to wait.`,
+			status:  client.RateLimited,
+			wait:    time.Minute + 5*time.Second,
+		},
+		"rate limited seconds": {
+			article: `You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 34s left to wait.`,
+			status:  client.RateLimited,
+			wait:    34 * time.Second,
+		},
+		"already solved": {
+			article: `You don't seem to be solving the right level.  Did you already complete it?`,
+			status:  client.AlreadySolved,
+		},
+	}
+
+	for name, s := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			verdict, err := client.ParseVerdict(page(s.article))
+			require.NoError(t, err)
+			assert.Equal(t, s.status, verdict.Status)
+			assert.Equal(t, s.wait, verdict.Wait)
+			assert.NotContains(t, verdict.Message, "<")
+		})
+	}
+
+	_, err := client.ParseVerdict(page("Something else"))
+	assert.Error(t, err, "Expected unknown verdict to be rejected")
+	_, err = client.ParseVerdict("<html></html>")
+	assert.Error(t, err, "Expected page without article to be rejected")
+}
+
+// TestSubmit tests that method Submit posts the answer and throttles the next answer
+func TestSubmit(t *testing.T) {
+	posts := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /2024/day/3/answer", func(w http.ResponseWriter, r *http.Request) {
+		posts++
+		assert.Equal(t, client.UserAgent, r.UserAgent())
+		assert.Equal(t, "2", r.FormValue("level"))
+		if r.FormValue("answer") == "48" {
+			_, _ = w.Write([]byte(page("That's the right answer!")))
+			return
+		}
+		_, _ = w.Write([]byte(page("That's not the right answer; your answer is too low.  Please wait one minute before trying again.")))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := client.New(srv.URL, "cookie")
+	verdict, err := c.Submit(context.Background(), 2024, 3, 2, "48")
+	require.NoError(t, err)
+	assert.Equal(t, client.Correct, verdict.Status)
+
+	c.Throttle = client.Throttle{File: filepath.Join(t.TempDir(), "wait")}
+	verdict, err = c.Submit(context.Background(), 2024, 3, 2, "12")
+	require.NoError(t, err)
+	assert.Equal(t, client.TooLow, verdict.Status)
+	assert.Equal(t, time.Minute, verdict.Wait)
+
+	verdict, err = c.Submit(context.Background(), 2024, 3, 2, "48")
+	require.NoError(t, err)
+	assert.Equal(t, client.RateLimited, verdict.Status, "Expected answer within the wait to be throttled")
+	assert.Positive(t, verdict.Wait)
+	assert.Equal(t, 2, posts, "Expected throttled answer not to be posted")
+
+	require.NoError(t, os.WriteFile(c.Throttle.File, []byte(time.Now().Add(-time.Second).Format(time.RFC3339)), 0o600))
+	verdict, err = c.Submit(context.Background(), 2024, 3, 2, "48")
+	require.NoError(t, err)
+	assert.Equal(t, client.Correct, verdict.Status, "Expected answer after the wait to be posted")
+}
@@ -0,0 +1,188 @@
+// Package config resolves the settings of the CLI. The precedence is
+// flags > AOC_* environment variables > config file > defaults,
+// the flags default to the Config resolved by Load.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"aoc2024/internal/client"
+	"aoc2024/internal/output"
+	"aoc2024/pkg/log"
+	"aoc2024/pkg/registry"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// File is the config file read from the working directory
+	File = ".aoc.yaml"
+	// FileEnv overrides the path of the config file, the file must exist when set
+	FileEnv = "AOC_CONFIG"
+	// DebugEnv set to true logs at debug level unless AOC_LOG_LEVEL is set
+	DebugEnv = "DEBUG"
+)
+
+// Environment variables overriding the config file
+const (
+	YearEnv      = "AOC_YEAR"
+	InputsEnv    = "AOC_INPUTS"
+	OutputEnv    = "AOC_OUTPUT"
+	LogLevelEnv  = "AOC_LOG_LEVEL"
+	LogFormatEnv = "AOC_LOG_FORMAT"
+	WorkersEnv   = "AOC_WORKERS"
+	TimeoutEnv   = "AOC_TIMEOUT"
+	SessionEnv   = "AOC_SESSION"
+	BaseURLEnv   = "AOC_BASE_URL"
+)
+
+// Config settings of the CLI e.g.
+//
+//	year: 2024
+//	inputs: inputs
+//	output: text
+//	log_level: info
+//	log_format: json
+//	workers: 1
+//	timeout: 30s
+//	session: 53616c7465645f5f...
+//	base_url: https://adventofcode.com
+type Config struct {
+	Year      int           `yaml:"year"`       // Year is the year of the solved days
+	Inputs    string        `yaml:"inputs"`     // Inputs is the directory of puzzle inputs named YYYY/dayNN.txt
+	Output    string        `yaml:"output"`     // Output is the format of the results
+	LogLevel  string        `yaml:"log_level"`  // LogLevel is debug, info, warn or error
+	LogFormat string        `yaml:"log_format"` // LogFormat is json or console
+	Workers   int           `yaml:"workers"`    // Workers is the number of days solved concurrently
+	Timeout   time.Duration `yaml:"timeout"`    // Timeout cuts off solving, no timeout when zero
+	Session   string        `yaml:"session"`    // Session is the session cookie of the logged in Advent of Code user
+	BaseURL   string        `yaml:"base_url"`   // BaseURL is the Advent of Code website
+}
+
+// Default returns the Config used when nothing is configured
+func Default() Config {
+	return Config{
+		Year:      registry.DefaultYear,
+		Inputs:    "inputs",
+		Output:    string(output.Text),
+		LogLevel:  log.InfoLevel.String(),
+		LogFormat: log.JSONFormat,
+		Workers:   1,
+		BaseURL:   client.DefaultBaseURL,
+	}
+}
+
+// Load resolves the Config from the defaults, the config file and the environment looked up with lookupEnv.
+// The config file is File unless FileEnv is set, a missing File is skipped.
+func Load(lookupEnv func(string) (string, bool)) (Config, error) {
+	cfg := Default()
+
+	file, required := lookupEnv(FileEnv)
+	if !required {
+		file = File
+	}
+	content, err := os.ReadFile(filepath.Clean(file))
+	switch {
+	case err == nil:
+		if err := cfg.decode(bytes.NewReader(content)); err != nil {
+			return cfg, fmt.Errorf("config %s: %w", file, err)
+		}
+	case required || !errors.Is(err, fs.ErrNotExist):
+		return cfg, fmt.Errorf("config: %w", err)
+	}
+
+	if err := cfg.environment(lookupEnv); err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.Validate()
+}
+
+// decode overrides cfg with the settings of the YAML config r, unknown settings are rejected
+func (c *Config) decode(r io.Reader) error {
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+// environment overrides cfg with the environment variables that are set
+func (c *Config) environment(lookupEnv func(string) (string, bool)) error {
+	if debug, ok := lookupEnv(DebugEnv); ok && debug == "true" {
+		c.LogLevel = log.DebugLevel.String()
+	}
+
+	for env, value := range map[string]*string{
+		InputsEnv:    &c.Inputs,
+		OutputEnv:    &c.Output,
+		LogLevelEnv:  &c.LogLevel,
+		LogFormatEnv: &c.LogFormat,
+		SessionEnv:   &c.Session,
+		BaseURLEnv:   &c.BaseURL,
+	} {
+		if v, ok := lookupEnv(env); ok {
+			*value = v
+		}
+	}
+
+	if v, ok := lookupEnv(YearEnv); ok {
+		year, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("%s: invalid year %q", YearEnv, v)
+		}
+		c.Year = year
+	}
+
+	if v, ok := lookupEnv(WorkersEnv); ok {
+		workers, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("%s: invalid number of workers %q", WorkersEnv, v)
+		}
+		c.Workers = workers
+	}
+
+	if v, ok := lookupEnv(TimeoutEnv); ok {
+		timeout, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", TimeoutEnv, err)
+		}
+		c.Timeout = timeout
+	}
+	return nil
+}
+
+// Validate checks every setting of cfg
+func (c Config) Validate() error {
+	if c.Year < registry.FirstYear {
+		return fmt.Errorf("config year must be %d or later, got %d", registry.FirstYear, c.Year)
+	}
+	if _, err := output.ParseFormat(c.Output); err != nil {
+		return fmt.Errorf("config output: %w", err)
+	}
+	if _, err := log.ParseLevel(c.LogLevel); err != nil {
+		return fmt.Errorf("config log_level: %w", err)
+	}
+	if !log.ValidFormat(c.LogFormat) {
+		return fmt.Errorf("config log_format must be %s or %s, got %q", log.JSONFormat, log.ConsoleFormat, c.LogFormat)
+	}
+	if c.Workers < 1 {
+		return fmt.Errorf("config workers must be at least 1, got %d", c.Workers)
+	}
+	if c.Timeout < 0 {
+		return fmt.Errorf("config timeout must not be negative, got %s", c.Timeout)
+	}
+	if u, err := url.Parse(c.BaseURL); err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("config base_url must be an absolute URL, got %q", c.BaseURL)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"aoc2024/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// env returns a lookup of the environment variables in vars
+func env(vars map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := vars[key]
+		return value, ok
+	}
+}
+
+// TestLoad tests for function Load
+func TestLoad(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "aoc.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("inputs: puzzles\noutput: json\nworkers: 4\ntimeout: 30s\n"), 0o600))
+
+	scenarios := map[string]struct {
+		env      map[string]string
+		expected config.Config
+	}{
+		"defaults": {
+			env:      map[string]string{},
+			expected: config.Default(),
+		},
+		"config file": {
+			env: map[string]string{config.FileEnv: file},
+			expected: config.Config{
+				Year: 2024, Inputs: "puzzles", Output: "json", LogLevel: "info", LogFormat: "json", Workers: 4, Timeout: 30 * time.Second,
+				BaseURL: "https://adventofcode.com",
+			},
+		},
+		"environment over config file": {
+			env: map[string]string{
+				config.FileEnv:      file,
+				config.OutputEnv:    "csv",
+				config.WorkersEnv:   "2",
+				config.LogFormatEnv: "console",
+				config.DebugEnv:     "true",
+				config.YearEnv:      "2023",
+				config.SessionEnv:   "cookie",
+				config.BaseURLEnv:   "http://localhost:8080",
+			},
+			expected: config.Config{
+				Year: 2023, Inputs: "puzzles", Output: "csv", LogLevel: "debug", LogFormat: "console", Workers: 2, Timeout: 30 * time.Second,
+				Session: "cookie", BaseURL: "http://localhost:8080",
+			},
+		},
+		"log level over debug": {
+			env: map[string]string{config.DebugEnv: "true", config.LogLevelEnv: "error"},
+			expected: func() config.Config {
+				cfg := config.Default()
+				cfg.LogLevel = "error"
+				return cfg
+			}(),
+		},
+	}
+
+	for name, s := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := config.Load(env(s.env))
+			require.NoError(t, err)
+			assert.Equal(t, s.expected, cfg)
+		})
+	}
+}
+
+// TestLoadErrors tests that Load rejects invalid configs
+func TestLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+	unknown := filepath.Join(dir, "unknown.yaml")
+	require.NoError(t, os.WriteFile(unknown, []byte("workerz: 4\n"), 0o600))
+
+	for name, vars := range map[string]map[string]string{
+		"missing config file": {config.FileEnv: filepath.Join(dir, "missing.yaml")},
+		"unknown setting":     {config.FileEnv: unknown},
+		"invalid year":        {config.YearEnv: "last"},
+		"year before aoc":     {config.YearEnv: "2014"},
+		"invalid workers":     {config.WorkersEnv: "many"},
+		"zero workers":        {config.WorkersEnv: "0"},
+		"invalid timeout":     {config.TimeoutEnv: "soon"},
+		"invalid output":      {config.OutputEnv: "yaml"},
+		"invalid log level":   {config.LogLevelEnv: "loud"},
+		"invalid log format":  {config.LogFormatEnv: "xml"},
+		"relative base url":   {config.BaseURLEnv: "adventofcode.com"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := config.Load(env(vars))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Package daytest runs the registered solver of a day against its example inputs and the real puzzle input
+package daytest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"aoc2024/internal/config"
+	"aoc2024/internal/run"
+	"aoc2024/internal/verify"
+	"aoc2024/pkg/solver"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// TestData is the folder of a day package holding the examples
+	TestData = "testdata"
+	// ExamplePattern matches the example inputs in TestData
+	ExamplePattern = "example*.txt"
+	// AnswersFile holds the expected answers of the examples, and of the real inputs of a year in InputsEnv
+	AnswersFile = "answers.json"
+	// InputsEnv is the directory of the real puzzle inputs, the real input tests are skipped when unset
+	InputsEnv = config.InputsEnv
+)
+
+// Answers expected answers keyed by example name and part, an empty answer is unknown e.g.
+//
+//	{"example.txt": {"1": "11", "2": "31"}}
+type Answers map[string]map[int]string
+
+// Examples returns the example inputs in dir and their expected answers
+func Examples(dir string) ([]string, Answers, error) {
+	files, err := filepath.Glob(filepath.Join(dir, ExamplePattern))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	answers := Answers{}
+	content, err := os.ReadFile(filepath.Join(dir, AnswersFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return files, answers, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := json.Unmarshal(content, &answers); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode answers %s: %w", filepath.Join(dir, AnswersFile), err)
+	}
+	return files, answers, nil
+}
+
+// Run solves every example in TestData of the package under test with the registered solver of day in year
+func Run(t *testing.T, year, day int) {
+	t.Helper()
+
+	files, answers, err := Examples(TestData)
+	require.NoError(t, err)
+	require.NotEmpty(t, files, "%d day %d has no %s examples", year, day, filepath.Join(TestData, ExamplePattern))
+
+	for _, file := range files {
+		name := filepath.Base(file)
+		t.Run(name, func(t *testing.T) {
+			expected, ok := answers[name]
+			require.True(t, ok, "no expected answers for %s in %s", name, AnswersFile)
+			check(t, year, day, file, expected)
+		})
+	}
+}
+
+// RunInput solves the real puzzle input YYYY/dayNN.txt in InputsEnv, the answers are checked
+// when the YYYY folder has an answers file in the verify format
+func RunInput(t *testing.T, year, day int) {
+	t.Helper()
+
+	dir := os.Getenv(InputsEnv)
+	if dir == "" {
+		t.Skipf("%s is not set", InputsEnv)
+	}
+	file := run.Input{Dir: dir}.Filename(year, day)
+	if _, err := os.Stat(file); err != nil {
+		t.Skipf("no puzzle input %s", file)
+	}
+
+	answers, err := verify.Load(verify.Filename(dir, year))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		require.NoError(t, err)
+	}
+	check(t, year, day, file, answers[day][filepath.Base(file)])
+}
+
+// check solves file and compares the answer of every part with its expected answer
+func check(t *testing.T, year, day int, file string, expected map[int]string) {
+	t.Helper()
+
+	report, err := run.Day(context.Background(), year, day, file, run.AllParts)
+	require.NoError(t, err)
+
+	for _, result := range report.Results {
+		if result.Part == solver.PartParse {
+			require.NoError(t, result.Err, "failed to parse %s", file)
+			continue
+		}
+
+		t.Run(result.Stage(), func(t *testing.T) {
+			if expected[result.Part] == "" {
+				t.Skip("no expected answer")
+			}
+			require.NoError(t, result.Err)
+			assert.Equal(t, expected[result.Part], result.Answer.String())
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"aoc2024/internal/run"
+)
+
+type (
+	// JSONResult is a stage of a day in the JSON output
+	JSONResult struct {
+		Year       int    `json:"year"`
+		Day        int    `json:"day"`
+		Part       string `json:"part"`
+		File       string `json:"file"`
+		Answer     string `json:"answer"`
+		DurationNs int64  `json:"duration_ns"`
+		Error      string `json:"error,omitempty"`
+	}
+	// JSONReport is the JSON output of the results
+	JSONReport struct {
+		Results []JSONResult `json:"results"`
+		TotalNs int64        `json:"total_ns"`
+	}
+)
+
+// writeJSON writes every stage as a JSON object together with the total time
+func writeJSON(w io.Writer, reports []run.Report) error {
+	out := JSONReport{
+		Results: []JSONResult{},
+		TotalNs: total(reports).Nanoseconds(),
+	}
+	for _, report := range reports {
+		for _, result := range report.Results {
+			out.Results = append(out.Results, JSONResult{
+				Year:       report.Year,
+				Day:        report.Day,
+				Part:       part(result),
+				File:       report.File,
+				Answer:     result.Answer.String(),
+				DurationNs: result.Duration.Nanoseconds(),
+				Error:      errorMessage(result),
+			})
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
+
+// ReadJSON decodes the JSON output of the results
+func ReadJSON(r io.Reader) (JSONReport, error) {
+	var report JSONReport
+	err := json.NewDecoder(r).Decode(&report)
+	return report, err
+}
+
+// writeCSV writes every stage as a CSV record with a header
+func writeCSV(w io.Writer, reports []run.Report) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"day", "part", "file", "answer", "duration_ns", "error"}); err != nil {
+		return err
+	}
+
+	for _, report := range reports {
+		for _, result := range report.Results {
+			if err := writer.Write([]string{
+				strconv.Itoa(report.Day),
+				part(result),
+				report.File,
+				result.Answer.String(),
+				strconv.FormatInt(result.Duration.Nanoseconds(), 10),
+				errorMessage(result),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,87 @@
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"aoc2024/internal/run"
+)
+
+type (
+	junitTestSuites struct {
+		XMLName xml.Name         `xml:"testsuites"`
+		Tests   int              `xml:"tests,attr"`
+		Errors  int              `xml:"errors,attr"`
+		Time    string           `xml:"time,attr"`
+		Suites  []junitTestSuite `xml:"testsuite"`
+	}
+	junitTestSuite struct {
+		Name   string          `xml:"name,attr"`
+		Tests  int             `xml:"tests,attr"`
+		Errors int             `xml:"errors,attr"`
+		Time   string          `xml:"time,attr"`
+		Cases  []junitTestCase `xml:"testcase"`
+	}
+	junitTestCase struct {
+		Name      string      `xml:"name,attr"`
+		ClassName string      `xml:"classname,attr"`
+		Time      string      `xml:"time,attr"`
+		Error     *junitError `xml:"error,omitempty"`
+		SystemOut string      `xml:"system-out,omitempty"`
+	}
+	junitError struct {
+		Message string `xml:"message,attr"`
+	}
+)
+
+const secondsPrecision = 6
+
+// seconds formats d as decimal seconds, JUnit readers do not accept exponents
+func seconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', secondsPrecision, 64)
+}
+
+// writeJUnit writes a JUnit XML report with a test suite per day and a test case per stage.
+// The answer of a stage is written to the system-out of its test case.
+func writeJUnit(w io.Writer, reports []run.Report) error {
+	suites := junitTestSuites{Time: seconds(total(reports))}
+	for _, report := range reports {
+		var suiteTime time.Duration
+
+		suite := junitTestSuite{Name: fmt.Sprintf("day%d", report.Day)}
+		for _, result := range report.Results {
+			testCase := junitTestCase{
+				Name:      result.Stage(),
+				ClassName: suite.Name,
+				Time:      seconds(result.Duration),
+				SystemOut: result.Answer.String(),
+			}
+			if result.Err != nil {
+				testCase.Error = &junitError{Message: result.Err.Error()}
+				suite.Errors++
+			}
+			suiteTime += result.Duration
+			suite.Cases = append(suite.Cases, testCase)
+		}
+		suite.Tests = len(suite.Cases)
+		suite.Time = seconds(suiteTime)
+
+		suites.Tests += suite.Tests
+		suites.Errors += suite.Errors
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,80 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"aoc2024/internal/run"
+	"aoc2024/pkg/solver"
+)
+
+// Format of the rendered results
+type Format string
+
+const (
+	Text     Format = "text"
+	JSON     Format = "json"
+	CSV      Format = "csv"
+	Markdown Format = "markdown"
+	JUnit    Format = "junit"
+)
+
+// Formats all supported formats
+var Formats = []Format{Text, JSON, CSV, Markdown, JUnit}
+
+// ParseFormat returns the format named by s
+func ParseFormat(s string) (Format, error) {
+	for _, format := range Formats {
+		if string(format) == strings.ToLower(s) {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported output format %q", s)
+}
+
+// Write renders the reports in format to w
+func Write(w io.Writer, format Format, reports []run.Report) error {
+	switch format {
+	case Text:
+		return writeText(w, reports)
+	case JSON:
+		return writeJSON(w, reports)
+	case CSV:
+		return writeCSV(w, reports)
+	case Markdown:
+		return writeMarkdown(w, reports)
+	case JUnit:
+		return writeJUnit(w, reports)
+	}
+	return fmt.Errorf("unsupported output format %q", format)
+}
+
+// part returns the part of a result as a column value, parse for the parse stage
+func part(result solver.Result) string {
+	if result.Part == solver.PartParse {
+		return "parse"
+	}
+	return strconv.Itoa(result.Part)
+}
+
+// errorMessage returns the error message of a result, empty if it succeeded
+func errorMessage(result solver.Result) string {
+	if result.Err == nil {
+		return ""
+	}
+	return result.Err.Error()
+}
+
+// total time of all stages of the reports
+func total(reports []run.Report) time.Duration {
+	var sum time.Duration
+	for _, report := range reports {
+		for _, result := range report.Results {
+			sum += result.Duration
+		}
+	}
+	return sum
+}
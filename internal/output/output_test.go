@@ -0,0 +1,91 @@
+package output_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"aoc2024/internal/output"
+	"aoc2024/internal/run"
+	"aoc2024/pkg/solver"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var reports = []run.Report{
+	{
+		Day:  1,
+		File: "day1.txt",
+		Results: []solver.Result{
+			{Part: solver.PartParse, Duration: time.Millisecond},
+			{Part: solver.Part1, Answer: solver.Int(11), Duration: time.Millisecond},
+			{Part: solver.Part2, Err: errors.New("failed"), Duration: time.Millisecond},
+		},
+	},
+}
+
+// TestParseFormat tests for function ParseFormat
+func TestParseFormat(t *testing.T) {
+	for _, format := range output.Formats {
+		parsed, err := output.ParseFormat(string(format))
+		require.NoError(t, err)
+		assert.Equal(t, format, parsed)
+	}
+
+	_, err := output.ParseFormat("yaml")
+	assert.Error(t, err, "Expected unsupported format to be rejected")
+}
+
+// TestWrite tests for function Write
+func TestWrite(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"Test Write JSON":   testWriteJSON,
+		"Test Write CSV":    testWriteCSV,
+		"Test Write Format": testWriteFormat,
+	} {
+		t.Run(scenario, func(t *testing.T) {
+			fn(t)
+		})
+	}
+}
+
+func testWriteJSON(t *testing.T) {
+	buf := bytes.Buffer{}
+	require.NoError(t, output.Write(&buf, output.JSON, reports))
+
+	var decoded struct {
+		Results []map[string]any `json:"results"`
+		TotalNs int64            `json:"total_ns"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+
+	require.Len(t, decoded.Results, 3)
+	assert.Equal(t, "11", decoded.Results[1]["answer"])
+	assert.Equal(t, "failed", decoded.Results[2]["error"])
+	assert.Equal(t, (3 * time.Millisecond).Nanoseconds(), decoded.TotalNs)
+}
+
+func testWriteCSV(t *testing.T) {
+	buf := bytes.Buffer{}
+	require.NoError(t, output.Write(&buf, output.CSV, reports))
+
+	assert.Equal(t, "day,part,file,answer,duration_ns,error\n"+
+		"1,parse,day1.txt,,1000000,\n"+
+		"1,1,day1.txt,11,1000000,\n"+
+		"1,2,day1.txt,,1000000,failed\n",
+		buf.String(),
+	)
+}
+
+func testWriteFormat(t *testing.T) {
+	for _, format := range output.Formats {
+		t.Run(string(format), func(t *testing.T) {
+			buf := bytes.Buffer{}
+			require.NoError(t, output.Write(&buf, format, reports))
+			assert.NotEmpty(t, buf.String())
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"aoc2024/internal/run"
+)
+
+const tablePadding = 2
+
+// writeText writes a table with the answer and wall time of every stage per day, followed by the total time.
+func writeText(w io.Writer, reports []run.Report) error {
+	table := tabwriter.NewWriter(w, 0, 0, tablePadding, ' ', 0)
+	fmt.Fprintln(table, "DAY\tPART\tANSWER\tTIME")
+
+	for _, report := range reports {
+		for _, result := range report.Results {
+			answer := result.Answer.String()
+			if result.Err != nil {
+				answer = "ERROR: " + result.Err.Error()
+			}
+			fmt.Fprintf(table, "%d\t%s\t%s\t%s\n", report.Day, part(result), answer, result.Duration)
+		}
+	}
+	fmt.Fprintf(table, "TOTAL\t\t\t%s\n", total(reports))
+
+	return table.Flush()
+}
+
+// writeMarkdown writes the same table as writeText as a Markdown table
+func writeMarkdown(w io.Writer, reports []run.Report) error {
+	builder := strings.Builder{}
+	builder.WriteString("| Day | Part | Answer | Time |\n")
+	builder.WriteString("| --: | :--- | :----- | ---: |\n")
+
+	for _, report := range reports {
+		for _, result := range report.Results {
+			answer := result.Answer.String()
+			if result.Err != nil {
+				answer = "**ERROR**: " + result.Err.Error()
+			}
+			// Pipes would split the cell
+			answer = strings.ReplaceAll(answer, "|", "\\|")
+			builder.WriteString(fmt.Sprintf("| %d | %s | %s | %s |\n", report.Day, part(result), answer, result.Duration))
+		}
+	}
+	builder.WriteString(fmt.Sprintf("| **Total** | | | %s |\n", total(reports)))
+
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
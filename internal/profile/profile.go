@@ -0,0 +1,101 @@
+// Package profile writes the pprof profiles and execution trace of a solver run
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+)
+
+// Options are the output files of the profiles, a profile is disabled when its file is empty
+type Options struct {
+	CPU   string
+	Mem   string
+	Block string
+	Trace string
+}
+
+// Profiler writes the profiles selected in Options between Start and Stop
+type Profiler struct {
+	opts  Options
+	cpu   *os.File
+	trace *os.File
+}
+
+// Start starts the CPU profile, the execution trace and the block profiling of opts.
+// Stop must be called to write the profiles, even when Start fails.
+func Start(opts Options) (*Profiler, error) {
+	p := &Profiler{opts: opts}
+
+	if opts.CPU != "" {
+		file, err := os.Create(filepath.Clean(opts.CPU))
+		if err != nil {
+			return p, fmt.Errorf("cpu profile: %w", err)
+		}
+		p.cpu = file
+		if err := pprof.StartCPUProfile(file); err != nil {
+			return p, fmt.Errorf("cpu profile: %w", err)
+		}
+	}
+
+	if opts.Trace != "" {
+		file, err := os.Create(filepath.Clean(opts.Trace))
+		if err != nil {
+			return p, fmt.Errorf("trace: %w", err)
+		}
+		p.trace = file
+		if err := trace.Start(file); err != nil {
+			return p, fmt.Errorf("trace: %w", err)
+		}
+	}
+
+	if opts.Block != "" {
+		// record every blocking event
+		runtime.SetBlockProfileRate(1)
+	}
+	return p, nil
+}
+
+// Stop stops the CPU profile and the execution trace, then writes the memory and block profiles
+func (p *Profiler) Stop() error {
+	errs := []error{}
+
+	if p.cpu != nil {
+		pprof.StopCPUProfile()
+		errs = append(errs, p.cpu.Close())
+	}
+
+	if p.trace != nil {
+		trace.Stop()
+		errs = append(errs, p.trace.Close())
+	}
+
+	if p.opts.Mem != "" {
+		// up-to-date statistics of the allocations
+		runtime.GC()
+		errs = append(errs, writeProfile("allocs", p.opts.Mem))
+	}
+
+	if p.opts.Block != "" {
+		errs = append(errs, writeProfile("block", p.opts.Block))
+		runtime.SetBlockProfileRate(0)
+	}
+	return errors.Join(errs...)
+}
+
+// writeProfile writes the named pprof profile to filename
+func writeProfile(name, filename string) error {
+	file, err := os.Create(filepath.Clean(filename))
+	if err != nil {
+		return fmt.Errorf("%s profile: %w", name, err)
+	}
+	if err := pprof.Lookup(name).WriteTo(file, 0); err != nil {
+		file.Close()
+		return fmt.Errorf("%s profile: %w", name, err)
+	}
+	return file.Close()
+}
@@ -1,35 +1,219 @@
 package run
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"path/filepath"
+	"runtime/pprof"
+	"strconv"
+	"strings"
+	"sync"
 
-	"aoc2024/days/day1"
-	"aoc2024/days/day2"
-	"aoc2024/days/day3"
-	"aoc2024/days/day4"
-	"aoc2024/days/day5"
-	"aoc2024/days/day6"
-	"aoc2024/days/day7"
-	"aoc2024/days/day8"
+	"aoc2024/pkg/log"
+	"aoc2024/pkg/reader"
+	"aoc2024/pkg/registry"
+	"aoc2024/pkg/solver"
 )
 
-type AdventSolveDay func(filename string)
+const (
+	// DayPlaceholder is replaced by the day number in the puzzle input filename
+	DayPlaceholder = "{day}"
+	// YearPlaceholder is replaced by the year in the puzzle input filename
+	YearPlaceholder = "{year}"
+	// AllParts selects every implemented part of a day
+	AllParts = 0
+	// AllPartsName is the name of AllParts in flags and queries
+	AllPartsName = "all"
+)
+
+// ParsePart parses a part selection, either 1, 2 or AllPartsName
+func ParsePart(s string) (int, error) {
+	switch s {
+	case AllPartsName:
+		return AllParts, nil
+	case "1", "2":
+		return strconv.Atoi(s)
+	}
+	return 0, fmt.Errorf("part must be 1, 2 or %s, got %q", AllPartsName, s)
+}
+
+// Report holds the results of every stage of a solved day
+type Report struct {
+	Year    int
+	Day     int
+	File    string
+	Results []solver.Result
+}
+
+// Failed reports whether any stage of the day failed
+func (r Report) Failed() bool {
+	for _, result := range r.Results {
+		if result.Err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// SelectDays returns every registered day of year if all is set, otherwise days when every day is solved in year.
+func SelectDays(year int, all bool, days []int) ([]int, error) {
+	if all {
+		if len(registry.Days(year)) == 0 {
+			return nil, fmt.Errorf("no solved days in %d", year)
+		}
+		return registry.Days(year), nil
+	}
+
+	for _, day := range days {
+		if _, ok := registry.Lookup(year, day); !ok {
+			return nil, fmt.Errorf("unrecognized or not solved day %v in %d", day, year)
+		}
+	}
+	return days, nil
+}
+
+// Input locates the puzzle input of a day
+type Input struct {
+	File string // File is the puzzle input path, reader.Stdin for stdin, DayPlaceholder and YearPlaceholder are replaced
+	Dir  string // Dir is the inputs directory searched for YYYY/dayNN.txt when File is empty
+	// Options set how the puzzle inputs are read e.g. their max line size
+	Options []reader.OptFunc
+}
+
+// Filename returns the puzzle input filename of day in year
+func (i Input) Filename(year, day int) string {
+	if i.File == "" {
+		return filepath.Join(i.Dir, strconv.Itoa(year), fmt.Sprintf("day%02d.txt", day))
+	}
+	return strings.NewReplacer(
+		DayPlaceholder, strconv.Itoa(day),
+		YearPlaceholder, strconv.Itoa(year),
+	).Replace(i.File)
+}
 
-var AdventOfDay = map[int]AdventSolveDay{
-	1: day1.AdventSolveDay1,
-	2: day2.AdventSolveDay2,
-	3: day3.AdventSolveDay3,
-	4: day4.AdventSolveDay4,
-	5: day5.AdventSolveDay5,
-	6: day6.AdventSolveDay6,
-	7: day7.AdventSolveDay7,
-	8: day8.AdventSolveDay8,
+// Validate checks that the input can be used for every selected day
+func (i Input) Validate(days []int) error {
+	if len(days) <= 1 || i.File == "" {
+		return nil
+	}
+	if i.File == reader.Stdin {
+		return errors.New("stdin can only be read when solving a single day")
+	}
+	if !strings.Contains(i.File, DayPlaceholder) {
+		return fmt.Errorf("file %q must contain %s when solving several days", i.File, DayPlaceholder)
+	}
+	return nil
 }
 
-func Day(day int, file string) {
-	if solve, ok := AdventOfDay[day]; ok {
-		solve(file)
-	} else {
-		fmt.Printf("Unrecognized or Not sovled day %v\n", day)
+// SelectParts returns the implemented parts of entry, part selects a single part or AllParts.
+func SelectParts(entry registry.Entry, part int) ([]int, error) {
+	if part == AllParts {
+		return entry.PartNumbers(), nil
+	}
+	if part < 1 || part > entry.Parts {
+		return nil, fmt.Errorf("%d day %d has no part %d", entry.Year, entry.Day, part)
+	}
+	return []int{part}, nil
+}
+
+// Day solves the selected part of day in year with the puzzle input file, returning the result of every stage.
+// The input is parsed once whichever parts are selected, failing to open file is reported as a failed parse stage.
+// Once ctx is done the running part is cut off and reported as failed, opts set how the input is read.
+func Day(ctx context.Context, year, day int, file string, part int, opts ...reader.OptFunc) (Report, error) {
+	if _, _, err := lookup(year, day, part); err != nil {
+		return Report{}, err
+	}
+
+	input, err := reader.Open(file, opts...)
+	if err != nil {
+		return Report{
+			Year:    year,
+			Day:     day,
+			File:    file,
+			Results: []solver.Result{{Part: solver.PartParse, Err: err}},
+		}, nil
+	}
+	defer input.Close()
+
+	return Solve(ctx, year, day, file, input, part)
+}
+
+// Solve solves the selected part of day in year with the puzzle input read from r like Day,
+// name is the input name in the report and in parse errors.
+func Solve(ctx context.Context, year, day int, name string, r io.Reader, part int) (Report, error) {
+	entry, parts, err := lookup(year, day, part)
+	if err != nil {
+		return Report{}, err
+	}
+	log.Info("Solving day", log.Int("year", year), log.Int("day", day), log.String("filename", name), log.Any("parts", parts))
+
+	report := Report{Year: year, Day: day, File: name}
+
+	// profiles of several days can be split with e.g. go tool pprof -tagfocus day=6
+	labels := pprof.Labels("year", strconv.Itoa(year), "day", strconv.Itoa(day))
+	pprof.Do(ctx, labels, func(ctx context.Context) {
+		report.Results = solver.Solve(ctx, entry.New(), r, parts)
+	})
+	if parse := report.Results[0]; parse.Err != nil {
+		var parseErr *reader.ParseError
+		if errors.As(parse.Err, &parseErr) && parseErr.File == "" {
+			parseErr.File = name
+		}
+		log.Error("Failed to parse input", log.Int("day", day), log.String("error", parse.Err.Error()))
+	}
+	return report, nil
+}
+
+// lookup returns the registered entry of day in year and its selected parts
+func lookup(year, day int, part int) (registry.Entry, []int, error) {
+	entry, ok := registry.Lookup(year, day)
+	if !ok {
+		return registry.Entry{}, nil, fmt.Errorf("unrecognized or not solved day %v in %d", day, year)
+	}
+	parts, err := SelectParts(entry, part)
+	return entry, parts, err
+}
+
+// All solves the selected part of every day in year with workers days solved concurrently, the reports are in the order of days.
+// Once ctx is done no more days are started.
+func All(ctx context.Context, year int, days []int, input Input, part int, workers int) ([]Report, error) {
+	if err := input.Validate(days); err != nil {
+		return nil, err
+	}
+	if workers < 1 {
+		return nil, fmt.Errorf("number of workers must be at least 1, got %d", workers)
+	}
+
+	var (
+		reports = make([]Report, len(days))
+		errs    = make([]error, len(days))
+		started = 0
+		wg      sync.WaitGroup
+		slots   = make(chan struct{}, workers)
+	)
+	for i, day := range days {
+		slots <- struct{}{}
+		if err := ctx.Err(); err != nil {
+			log.Warn("Stopped solving days", log.String("error", err.Error()), log.Int("next-day", day))
+			break
+		}
+
+		started++
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			reports[i], errs[i] = Day(ctx, year, day, input.Filename(year, day), part, input.Options...)
+		}()
+	}
+	wg.Wait()
+
+	for i := range started {
+		if errs[i] != nil {
+			return reports[:i], errs[i]
+		}
 	}
+	return reports[:started], nil
 }
@@ -0,0 +1,126 @@
+package run_test
+
+import (
+	"context"
+	"testing"
+
+	_ "aoc2024/days" // Register every solved day
+	"aoc2024/internal/run"
+	"aoc2024/pkg/registry"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSelectDays tests for function SelectDays
+func TestSelectDays(t *testing.T) {
+	days, err := run.SelectDays(registry.DefaultYear, true, nil)
+	require.NoError(t, err)
+	assert.Equal(t, registry.Days(registry.DefaultYear), days)
+
+	days, err = run.SelectDays(registry.DefaultYear, false, []int{1, 3})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 3}, days)
+
+	_, err = run.SelectDays(registry.DefaultYear, false, []int{1, registry.MaxDay})
+	assert.Error(t, err, "Expected unsolved day to be rejected")
+
+	_, err = run.SelectDays(registry.FirstYear, true, nil)
+	assert.Error(t, err, "Expected year without solved days to be rejected")
+	_, err = run.SelectDays(registry.FirstYear, false, []int{1})
+	assert.Error(t, err, "Expected day of another year to be rejected")
+}
+
+// TestSelectParts tests for function SelectParts
+func TestSelectParts(t *testing.T) {
+	entry := registry.Entry{Day: 1, Parts: 2}
+
+	parts, err := run.SelectParts(entry, run.AllParts)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, parts)
+
+	parts, err = run.SelectParts(entry, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2}, parts)
+
+	_, err = run.SelectParts(registry.Entry{Day: 1, Parts: 1}, 2)
+	assert.Error(t, err, "Expected unimplemented part to be rejected")
+}
+
+// TestParsePart tests for function ParsePart
+func TestParsePart(t *testing.T) {
+	for value, expected := range map[string]int{"1": 1, "2": 2, "all": run.AllParts} {
+		part, err := run.ParsePart(value)
+		require.NoError(t, err)
+		assert.Equal(t, expected, part)
+	}
+	_, err := run.ParsePart("3")
+	assert.EqualError(t, err, `part must be 1, 2 or all, got "3"`)
+}
+
+// TestInput tests for type Input
+func TestInput(t *testing.T) {
+	for _, test := range []struct {
+		description string
+		input       run.Input
+		day         int
+		expected    string
+	}{
+		{
+			description: "Default input discovery",
+			input:       run.Input{Dir: "inputs"},
+			day:         3,
+			expected:    "inputs/2024/day03.txt",
+		},
+		{
+			description: "Explicit file",
+			input:       run.Input{File: "puzzle.txt", Dir: "inputs"},
+			day:         3,
+			expected:    "puzzle.txt",
+		},
+		{
+			description: "Day placeholder",
+			input:       run.Input{File: "input/{day}.txt"},
+			day:         12,
+			expected:    "input/12.txt",
+		},
+		{
+			description: "Year placeholder",
+			input:       run.Input{File: "input/{year}-{day}.txt"},
+			day:         12,
+			expected:    "input/2024-12.txt",
+		},
+	} {
+		t.Run(test.description, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.input.Filename(registry.DefaultYear, test.day))
+		})
+	}
+
+	t.Run("Validate several days", func(t *testing.T) {
+		days := []int{1, 2}
+		assert.NoError(t, run.Input{Dir: "inputs"}.Validate(days))
+		assert.NoError(t, run.Input{File: "day{day}.txt"}.Validate(days))
+		assert.Error(t, run.Input{File: "-"}.Validate(days), "Expected stdin to be rejected for several days")
+		assert.Error(t, run.Input{File: "day.txt"}.Validate(days), "Expected file without placeholder to be rejected")
+		assert.NoError(t, run.Input{File: "-"}.Validate([]int{1}))
+	})
+}
+
+// TestAll tests that function All keeps the order of days whatever the number of workers
+func TestAll(t *testing.T) {
+	input := run.Input{File: "../../days/{year}/day{day}/testdata/example.txt"}
+	days := registry.Days(registry.DefaultYear)
+
+	for _, workers := range []int{1, 3} {
+		reports, err := run.All(context.Background(), registry.DefaultYear, days, input, run.AllParts, workers)
+		require.NoError(t, err)
+		require.Len(t, reports, len(days))
+		for i, report := range reports {
+			assert.Equal(t, days[i], report.Day)
+			assert.False(t, report.Failed(), "day %d failed with %d workers", report.Day, workers)
+		}
+	}
+
+	_, err := run.All(context.Background(), registry.DefaultYear, days, input, run.AllParts, 0)
+	assert.Error(t, err, "Expected zero workers to be rejected")
+}
@@ -0,0 +1,158 @@
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"aoc2024/pkg/registry"
+)
+
+const (
+	dirPermissions  = 0o750
+	filePermissions = 0o600
+
+	// testData and answersFile follow the example layout of internal/daytest
+	testData    = "testdata"
+	answersFile = "answers.json"
+
+	exampleAnswers = `{
+  "example.txt": {
+    "1": "",
+    "2": ""
+  }
+}
+`
+)
+
+//go:embed templates/*.tmpl
+var templates embed.FS
+
+// Day describes the day package to generate
+type Day struct {
+	Year  int
+	Day   int
+	Title string
+}
+
+// New generates the package of day under root/days/YYYY with a solver, an example test and a testdata folder,
+// then registers it in root/days/days.go. Existing days are never overwritten, the day package is removed
+// again when it can't be generated or registered.
+func New(root string, day Day) (created []string, err error) {
+	if day.Day < 1 || day.Day > registry.MaxDay {
+		return nil, fmt.Errorf("day must be between 1 and %d, got %d", registry.MaxDay, day.Day)
+	}
+	if day.Year < registry.FirstYear {
+		return nil, fmt.Errorf("year must be %d or later, got %d", registry.FirstYear, day.Year)
+	}
+	if strings.ContainsAny(day.Title, "\r\n") {
+		return nil, fmt.Errorf("title must be a single line, got %q", day.Title)
+	}
+	if _, err := os.Stat(filepath.Join(root, "go.mod")); err != nil {
+		return nil, fmt.Errorf("%s is not the repository root: %w", root, err)
+	}
+
+	dir := filepath.Join(root, "days", strconv.Itoa(day.Year), fmt.Sprintf("day%d", day.Day))
+	if _, err := os.Stat(dir); err == nil {
+		return nil, fmt.Errorf("%d day %d already exists in %s", day.Year, day.Day, dir)
+	}
+
+	// render every template before creating anything so a failure leaves no files behind
+	sources := map[string][]byte{}
+	for name, filename := range map[string]string{
+		"day.go.tmpl":      filepath.Join(dir, fmt.Sprintf("day%d.go", day.Day)),
+		"day_test.go.tmpl": filepath.Join(dir, fmt.Sprintf("day%d_test.go", day.Day)),
+	} {
+		source, err := generate(name, filename, day)
+		if err != nil {
+			return nil, err
+		}
+		sources[filename] = source
+	}
+	// expected answers of the example, filled in once known
+	sources[filepath.Join(dir, testData, "example.txt")] = []byte{}
+	sources[filepath.Join(dir, testData, answersFile)] = []byte(exampleAnswers)
+
+	if err := os.MkdirAll(filepath.Join(dir, testData), dirPermissions); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			created = nil
+			err = errors.Join(err, os.RemoveAll(dir))
+		}
+	}()
+
+	for _, filename := range slices.Sorted(maps.Keys(sources)) {
+		if err := os.WriteFile(filename, sources[filename], filePermissions); err != nil {
+			return nil, err
+		}
+		created = append(created, filename)
+	}
+
+	days := filepath.Join(root, "days", "days.go")
+	if err := register(days, day); err != nil {
+		return nil, err
+	}
+	return append(created, days), nil
+}
+
+// generate executes the template name into the formatted Go source of filename
+func generate(name, filename string, day Day) ([]byte, error) {
+	tmpl, err := template.ParseFS(templates, "templates/"+name)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.Buffer{}
+	if err := tmpl.Execute(&buf, day); err != nil {
+		return nil, err
+	}
+
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format %s: %w", filename, err)
+	}
+	return source, nil
+}
+
+// register adds the import of the day package to the days package, gofmt keeps the imports sorted
+func register(filename string, day Day) error {
+	content, err := os.ReadFile(filepath.Clean(filename))
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(string(content), "\n")
+	start, end := -1, -1
+	for i, line := range lines {
+		if strings.HasPrefix(line, "import (") {
+			start = i
+		} else if start != -1 && line == ")" {
+			end = i
+			break
+		}
+	}
+	if start == -1 || end == -1 {
+		return errors.New("no import block found in " + filename)
+	}
+
+	comment := strings.TrimSpace(fmt.Sprintf("%d Day %d %s", day.Year, day.Day, day.Title))
+	registered := append([]string{}, lines[:end]...)
+	registered = append(registered, fmt.Sprintf("\t_ \"aoc2024/days/%d/day%d\" // %s", day.Year, day.Day, comment))
+	registered = append(registered, lines[end:]...)
+	source, err := format.Source([]byte(strings.Join(registered, "\n")))
+	if err != nil {
+		return fmt.Errorf("failed to format %s: %w", filename, err)
+	}
+	return os.WriteFile(filename, source, filePermissions)
+}
@@ -0,0 +1,99 @@
+package scaffold_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"aoc2024/internal/scaffold"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const daysSource = `// Package days registers the solver of every solved day, import it for its side effects.
+package days
+
+import (
+	_ "aoc2024/days/2024/day1" // 2024 Day 1 Historian Hysteria
+)
+`
+
+// newRoot returns a repository root in a temporary directory with the days package source
+func newRoot(t *testing.T, days string) string {
+	t.Helper()
+
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module aoc2024\n"), 0o600))
+	require.NoError(t, os.Mkdir(filepath.Join(root, "days"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "days", "days.go"), []byte(days), 0o600))
+	return root
+}
+
+// TestNew tests that the generated day builds in this repository, the title is quoted in the source
+func TestNew(t *testing.T) {
+	root := newRoot(t, daysSource)
+	day := scaffold.Day{Year: 2099, Day: 10, Title: `Say "hi"`}
+
+	created, err := scaffold.New(root, day)
+	require.NoError(t, err)
+	dir := filepath.Join(root, "days", "2099", "day10")
+	assert.ElementsMatch(t, []string{
+		filepath.Join(dir, "day10.go"),
+		filepath.Join(dir, "day10_test.go"),
+		filepath.Join(dir, "testdata", "example.txt"),
+		filepath.Join(dir, "testdata", "answers.json"),
+		filepath.Join(root, "days", "days.go"),
+	}, created)
+
+	days, err := os.ReadFile(filepath.Join(root, "days", "days.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(days), `_ "aoc2024/days/2099/day10" // 2099 Day 10 Say "hi"`)
+
+	_, err = scaffold.New(root, day)
+	assert.ErrorContains(t, err, "already exists")
+
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go is not installed")
+	}
+
+	// build the generated files in place of days/2099/day10 of this repository
+	repo, err := filepath.Abs(filepath.Join("..", ".."))
+	require.NoError(t, err)
+	overlay := map[string]map[string]string{"Replace": {}}
+	for _, name := range []string{"day10.go", "day10_test.go"} {
+		overlay["Replace"][filepath.Join(repo, "days", "2099", "day10", name)] = filepath.Join(dir, name)
+	}
+	content, err := json.Marshal(overlay)
+	require.NoError(t, err)
+	overlayFile := filepath.Join(t.TempDir(), "overlay.json")
+	require.NoError(t, os.WriteFile(overlayFile, content, 0o600))
+
+	// compile the test binary too, it type-checks the generated test
+	cmd := exec.Command(goTool, "test", "-vet=off", "-overlay", overlayFile, "-c", "-o", filepath.Join(t.TempDir(), "day10.test"), "./days/2099/day10")
+	cmd.Dir = repo
+	output, err := cmd.CombinedOutput()
+	assert.NoError(t, err, "generated day doesn't build:\n%s", output)
+}
+
+// TestNewFailure tests that a day which can't be registered is removed so it can be generated again
+func TestNewFailure(t *testing.T) {
+	root := newRoot(t, "package days\n")
+	day := scaffold.Day{Year: 2099, Day: 10, Title: "Retry"}
+
+	created, err := scaffold.New(root, day)
+	assert.ErrorContains(t, err, "no import block")
+	assert.Empty(t, created)
+	assert.NoDirExists(t, filepath.Join(root, "days", "2099", "day10"))
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "days", "days.go"), []byte(daysSource), 0o600))
+	_, err = scaffold.New(root, day)
+	assert.NoError(t, err)
+
+	_, err = scaffold.New(root, scaffold.Day{Year: 2099, Day: 11, Title: "Two\nlines"})
+	assert.Error(t, err, "Expected a title of several lines to be rejected")
+	assert.NoDirExists(t, filepath.Join(root, "days", "2099", "day11"))
+}
@@ -0,0 +1,159 @@
+// Package server exposes the registered solvers over an HTTP API
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"aoc2024/internal/output"
+	"aoc2024/internal/run"
+	"aoc2024/pkg/log"
+	"aoc2024/pkg/registry"
+	"aoc2024/pkg/solver"
+)
+
+// MaxInputSize is the largest puzzle input accepted in a request body
+const MaxInputSize = 10 << 20
+
+// Day is a registered day in the list of days
+type Day struct {
+	Year  int      `json:"year"`
+	Day   int      `json:"day"`
+	Title string   `json:"title"`
+	Parts int      `json:"parts"`
+	Tags  []string `json:"tags"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// New returns the handler of the API, solving is cut off after timeout, no timeout when zero.
+//
+//	GET  /days              lists the registered days of every year, ?year=2024 selects a year
+//	POST /days/{day}/solve  solves the puzzle input in the body, ?part=1 selects a single part and ?year=2023 the year
+//
+// Solved days are answered with the JSON output of the run command, with status 422 when the input fails to parse.
+func New(timeout time.Duration) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /days", listDays)
+	mux.HandleFunc("POST /days/{day}/solve", func(w http.ResponseWriter, r *http.Request) {
+		solveDay(w, r, timeout)
+	})
+	return mux
+}
+
+// listDays answers the registered days
+func listDays(w http.ResponseWriter, r *http.Request) {
+	year := 0
+	if value := r.URL.Query().Get("year"); value != "" {
+		var err error
+		if year, err = strconv.Atoi(value); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid year %q", value))
+			return
+		}
+	}
+
+	days := []Day{}
+	for _, entry := range registry.Entries() {
+		if year != 0 && entry.Year != year {
+			continue
+		}
+		tags := entry.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		days = append(days, Day{
+			Year:  entry.Year,
+			Day:   entry.Day,
+			Title: entry.Title,
+			Parts: entry.Parts,
+			Tags:  tags,
+		})
+	}
+	writeJSON(w, http.StatusOK, days)
+}
+
+// solveDay answers the results of solving the puzzle input in the request body
+func solveDay(w http.ResponseWriter, r *http.Request, timeout time.Duration) {
+	day, err := strconv.Atoi(r.PathValue("day"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid day %q", r.PathValue("day")))
+		return
+	}
+	year := registry.DefaultYear
+	if value := r.URL.Query().Get("year"); value != "" {
+		if year, err = strconv.Atoi(value); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid year %q", value))
+			return
+		}
+	}
+	if _, ok := registry.Lookup(year, day); !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unrecognized or not solved day %v in %d", day, year))
+		return
+	}
+
+	part := run.AllParts
+	if value := r.URL.Query().Get("part"); value != "" {
+		if part, err = run.ParsePart(value); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	ctx := r.Context()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	input, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxInputSize))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("puzzle input exceeds %d bytes", tooLarge.Limit))
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	report, err := run.Solve(ctx, year, day, "body", bytes.NewReader(input), part)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// an input failing to parse is answered with its results too, the parse stage holds the error
+	status := http.StatusOK
+	if report.Results[0].Part == solver.PartParse && report.Results[0].Err != nil {
+		status = http.StatusUnprocessableEntity
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := output.Write(w, output.JSON, []run.Report{report}); err != nil {
+		log.Error("Failed to write results", log.Int("day", day), log.String("error", err.Error()))
+	}
+}
+
+// writeJSON answers v as JSON with status
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Error("Failed to write response", log.String("error", err.Error()))
+	}
+}
+
+// writeError answers err as a JSON error with status
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
@@ -0,0 +1,77 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	_ "aoc2024/days" // Register every solved day
+	"aoc2024/internal/output"
+	"aoc2024/internal/server"
+	"aoc2024/pkg/registry"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestListDays tests for endpoint GET /days
+func TestListDays(t *testing.T) {
+	srv := httptest.NewServer(server.New(0))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/days")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	days := []server.Day{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&days))
+	require.Len(t, days, len(registry.Entries()))
+	assert.Equal(t, "Historian Hysteria", days[0].Title)
+}
+
+// TestSolveDay tests for endpoint POST /days/{day}/solve
+func TestSolveDay(t *testing.T) {
+	srv := httptest.NewServer(server.New(0))
+	defer srv.Close()
+
+	example, err := os.ReadFile("../../days/2024/day1/testdata/example.txt")
+	require.NoError(t, err)
+
+	resp, err := http.Post(srv.URL+"/days/1/solve?part=2", "text/plain", strings.NewReader(string(example)))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	report, err := output.ReadJSON(resp.Body)
+	require.NoError(t, err)
+	require.Len(t, report.Results, 2)
+	assert.Equal(t, "parse", report.Results[0].Part)
+	assert.Equal(t, "2", report.Results[1].Part)
+	assert.Equal(t, "31", report.Results[1].Answer)
+
+	scenarios := map[string]struct {
+		path   string
+		body   string
+		status int
+	}{
+		"invalid day":   {path: "/days/x/solve", status: http.StatusBadRequest},
+		"unknown day":   {path: "/days/25/solve", status: http.StatusNotFound},
+		"invalid part":  {path: "/days/1/solve?part=3", status: http.StatusBadRequest},
+		"invalid year":  {path: "/days/1/solve?year=last", status: http.StatusBadRequest},
+		"unknown year":  {path: "/days/1/solve?year=2015", status: http.StatusNotFound},
+		"too large":     {path: "/days/1/solve", body: strings.Repeat("1   2\n", server.MaxInputSize/6+1), status: http.StatusRequestEntityTooLarge},
+		"invalid input": {path: "/days/1/solve", body: "1 x\n", status: http.StatusUnprocessableEntity},
+	}
+	for name, s := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			resp, err := http.Post(srv.URL+s.path, "text/plain", strings.NewReader(s.body))
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, s.status, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package verify
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+
+	"aoc2024/internal/run"
+	"aoc2024/pkg/solver"
+)
+
+// Status of a verified part
+type Status string
+
+const (
+	Pass    Status = "PASS"
+	Fail    Status = "FAIL"
+	Missing Status = "MISSING"
+
+	tablePadding = 2
+)
+
+// AnswersFile is the name of the answers file of a year in the inputs directory
+const AnswersFile = "answers.json"
+
+// Answers expected answers of a year keyed by day, input name and part, e.g.
+//
+//	{"1": {"input.txt": {"1": "11", "2": "31"}}}
+type Answers map[int]map[string]map[int]string
+
+// Filename returns the answers file of year in the inputs directory dir, YYYY/answers.json
+func Filename(dir string, year int) string {
+	return filepath.Join(dir, strconv.Itoa(year), AnswersFile)
+}
+
+// Load reads the expected answers from a JSON file
+func Load(filename string) (Answers, error) {
+	content, err := os.ReadFile(filepath.Clean(filename))
+	if err != nil {
+		return nil, err
+	}
+
+	answers := Answers{}
+	if err := json.Unmarshal(content, &answers); err != nil {
+		return nil, fmt.Errorf("failed to decode answers %s: %w", filename, err)
+	}
+	return answers, nil
+}
+
+// Expected returns the expected answer of a day part for input
+func (a Answers) Expected(day int, input string, part int) (string, bool) {
+	expected, ok := a[day][input][part]
+	return expected, ok
+}
+
+// Check is the verification of a single day part
+type Check struct {
+	Year     int
+	Day      int
+	Input    string
+	Part     int
+	Status   Status
+	Expected string
+	Actual   string
+	Err      error
+}
+
+// Verify compares the answers in reports with the expected answers of their year.
+// The input name of a report is the base name of its puzzle input file.
+// A failed parse stage is reported as a failure of the parse stage since no parts were run.
+func Verify(answers Answers, reports []run.Report) []Check {
+	checks := []Check{}
+	for _, report := range reports {
+		input := filepath.Base(report.File)
+		for _, result := range report.Results {
+			if result.Part == solver.PartParse && result.Err == nil {
+				continue
+			}
+
+			check := Check{
+				Year:   report.Year,
+				Day:    report.Day,
+				Input:  input,
+				Part:   result.Part,
+				Actual: result.Answer.String(),
+				Err:    result.Err,
+			}
+			expected, ok := answers.Expected(report.Day, input, result.Part)
+			check.Expected = expected
+
+			switch {
+			case result.Err != nil:
+				check.Status = Fail
+			case !ok:
+				check.Status = Missing
+			case expected == check.Actual:
+				check.Status = Pass
+			default:
+				check.Status = Fail
+			}
+			checks = append(checks, check)
+		}
+	}
+	return checks
+}
+
+// Failed reports whether any check failed
+func Failed(checks []Check) bool {
+	for _, check := range checks {
+		if check.Status == Fail {
+			return true
+		}
+	}
+	return false
+}
+
+// PrintChecks writes a table of every check
+func PrintChecks(w io.Writer, checks []Check) error {
+	table := tabwriter.NewWriter(w, 0, 0, tablePadding, ' ', 0)
+	fmt.Fprintln(table, "YEAR\tDAY\tINPUT\tPART\tSTATUS\tEXPECTED\tACTUAL")
+
+	for _, check := range checks {
+		actual := check.Actual
+		if check.Err != nil {
+			actual = "ERROR: " + check.Err.Error()
+		}
+		fmt.Fprintf(table, "%d\t%d\t%s\t%d\t%s\t%s\t%s\n",
+			check.Year, check.Day, check.Input, check.Part, check.Status, check.Expected, actual,
+		)
+	}
+
+	return table.Flush()
+}
@@ -0,0 +1,83 @@
+package verify_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"aoc2024/internal/run"
+	"aoc2024/internal/verify"
+	"aoc2024/pkg/solver"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestVerify tests for function Verify
+func TestVerify(t *testing.T) {
+	answers := verify.Answers{
+		1: {"input.txt": {1: "11", 2: "31"}},
+	}
+
+	for _, test := range []struct {
+		description string
+		results     []solver.Result
+		expected    []verify.Status
+		failed      bool
+	}{
+		{
+			description: "All parts pass",
+			results: []solver.Result{
+				{Part: solver.PartParse},
+				{Part: solver.Part1, Answer: solver.Int(11)},
+				{Part: solver.Part2, Answer: solver.Int(31)},
+			},
+			expected: []verify.Status{verify.Pass, verify.Pass},
+		},
+		{
+			description: "Mismatched answer",
+			results: []solver.Result{
+				{Part: solver.PartParse},
+				{Part: solver.Part1, Answer: solver.Int(11)},
+				{Part: solver.Part2, Answer: solver.String("32")},
+			},
+			expected: []verify.Status{verify.Pass, verify.Fail},
+			failed:   true,
+		},
+		{
+			description: "Failed parse",
+			results: []solver.Result{
+				{Part: solver.PartParse, Err: errors.New("bad input")},
+			},
+			expected: []verify.Status{verify.Fail},
+			failed:   true,
+		},
+	} {
+		t.Run(test.description, func(t *testing.T) {
+			checks := verify.Verify(answers, []run.Report{{Year: 2023, Day: 1, File: "inputs/input.txt", Results: test.results}})
+
+			statuses := []verify.Status{}
+			for _, check := range checks {
+				statuses = append(statuses, check.Status)
+				assert.Equal(t, 2023, check.Year)
+			}
+			assert.Equal(t, test.expected, statuses)
+			assert.Equal(t, test.failed, verify.Failed(checks))
+		})
+	}
+
+	t.Run("Missing answer", func(t *testing.T) {
+		checks := verify.Verify(answers, []run.Report{{
+			Day:     2,
+			File:    "input.txt",
+			Results: []solver.Result{{Part: solver.Part1, Answer: solver.Int(1)}},
+		}})
+
+		assert.Equal(t, verify.Missing, checks[0].Status)
+		assert.False(t, verify.Failed(checks), "Missing answers should not fail verification")
+	})
+}
+
+// TestFilename tests that the answers file is kept per year in the inputs directory
+func TestFilename(t *testing.T) {
+	assert.Equal(t, filepath.Join("inputs", "2023", "answers.json"), verify.Filename("inputs", 2023))
+}
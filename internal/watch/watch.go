@@ -0,0 +1,205 @@
+// Package watch rebuilds the CLI and solves a day again whenever its sources or puzzle input change
+package watch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"aoc2024/internal/output"
+)
+
+const tablePadding = 2
+
+// Watcher polls the sources of a day package and its puzzle input
+type Watcher struct {
+	Root     string        // Root is the repository root holding go.mod
+	Year     int           // Year is the year of the watched day
+	Day      int           // Day is the watched day
+	Part     int           // Part is the solved part, 0 for every part
+	File     string        // File is the puzzle input
+	Timeout  time.Duration // Timeout cuts off a run, no timeout when zero
+	Interval time.Duration // Interval between polls
+	RunArgs  []string      // RunArgs are extra flags of every run e.g. -mmap
+	Out      io.Writer     // Out receives the answers of every run
+	Err      io.Writer     // Err receives the build errors and logs of every run
+}
+
+// Files returns the watched files, the Go files of the day package and the puzzle input
+func (w *Watcher) Files() ([]string, error) {
+	dir := filepath.Join(w.Root, "days", strconv.Itoa(w.Year))
+	sources, err := filepath.Glob(filepath.Join(dir, fmt.Sprintf("day%d", w.Day), "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("%d day %d has no package in %s", w.Year, w.Day, dir)
+	}
+	return append(sources, w.File), nil
+}
+
+// Snapshot returns the modification time of files, the zero time for a missing file
+func Snapshot(files []string) map[string]time.Time {
+	snapshot := map[string]time.Time{}
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			snapshot[file] = time.Time{}
+			continue
+		}
+		snapshot[file] = info.ModTime()
+	}
+	return snapshot
+}
+
+// Changed returns the files of current that were added, removed or modified since previous
+func Changed(previous, current map[string]time.Time) []string {
+	changed := []string{}
+	for file, modified := range current {
+		if before, ok := previous[file]; !ok || !before.Equal(modified) {
+			changed = append(changed, file)
+		}
+	}
+	for file := range previous {
+		if _, ok := current[file]; !ok {
+			changed = append(changed, file)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// Run solves the day and solves it again on every change until ctx is done
+func (w *Watcher) Run(ctx context.Context) error {
+	dir, err := os.MkdirTemp("", "aoc-watch")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	binary := filepath.Join(dir, "aoc")
+
+	var (
+		snapshot map[string]time.Time
+		previous []output.JSONResult
+	)
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		files, err := w.Files()
+		if err != nil {
+			return err
+		}
+
+		current := Snapshot(files)
+		if changed := Changed(snapshot, current); snapshot == nil || len(changed) > 0 {
+			if snapshot != nil {
+				fmt.Fprintf(w.Out, "\nchanged %s\n", strings.Join(changed, ", "))
+			}
+			snapshot = current
+
+			results, err := w.solve(ctx, binary)
+			switch {
+			case ctx.Err() != nil:
+				return nil
+			case err != nil:
+				fmt.Fprintln(w.Out, err)
+			default:
+				if err := Diff(w.Out, previous, results); err != nil {
+					return err
+				}
+				previous = results
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// solve builds the CLI into binary and solves the day with it
+func (w *Watcher) solve(ctx context.Context, binary string) ([]output.JSONResult, error) {
+	build := exec.CommandContext(ctx, "go", "build", "-o", binary, "./cmd/aoc")
+	build.Dir = w.Root
+	build.Stderr = w.Err
+	if err := build.Run(); err != nil {
+		return nil, fmt.Errorf("build failed: %w", err)
+	}
+
+	part := "all"
+	if w.Part != 0 {
+		part = strconv.Itoa(w.Part)
+	}
+
+	var stdout bytes.Buffer
+	args := []string{"run",
+		"-year", strconv.Itoa(w.Year),
+		"-day", strconv.Itoa(w.Day),
+		"-part", part,
+		"-file", w.File,
+		"-timeout", w.Timeout.String(),
+		"-output", string(output.JSON),
+	}
+	solve := exec.CommandContext(ctx, binary, append(args, w.RunArgs...)...)
+	solve.Stdout = &stdout
+	solve.Stderr = w.Err
+
+	// a failed part exits non-zero but still writes its results
+	runErr := solve.Run()
+	var exitErr *exec.ExitError
+	if runErr != nil && !errors.As(runErr, &exitErr) {
+		return nil, runErr
+	}
+
+	report, err := output.ReadJSON(&stdout)
+	if err != nil && runErr != nil {
+		return nil, fmt.Errorf("run failed: %w", runErr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode results: %w", err)
+	}
+	return report.Results, nil
+}
+
+// Diff writes the answers of current next to their previous answers
+func Diff(w io.Writer, previous, current []output.JSONResult) error {
+	answers := map[string]string{}
+	for _, result := range previous {
+		answers[result.Part] = answer(result)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, tablePadding, ' ', 0)
+	fmt.Fprintln(tw, "PART\tANSWER\tPREVIOUS\tTIME")
+	for _, result := range current {
+		before, ok := answers[result.Part]
+		switch {
+		case !ok:
+			before = "-"
+		case before == answer(result):
+			before = "unchanged"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+			result.Part, answer(result), before, time.Duration(result.DurationNs))
+	}
+	return tw.Flush()
+}
+
+// answer returns the answer of result, or its error
+func answer(result output.JSONResult) string {
+	if result.Error != "" {
+		return "ERROR: " + result.Error
+	}
+	return result.Answer
+}
@@ -0,0 +1,38 @@
+package watch_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"aoc2024/internal/output"
+	"aoc2024/internal/watch"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestChanged tests for function Changed
+func TestChanged(t *testing.T) {
+	now := time.Now()
+	previous := map[string]time.Time{"day1.go": now, "input.txt": now, "removed.go": now}
+	current := map[string]time.Time{"day1.go": now, "input.txt": now.Add(time.Second), "added.go": now}
+
+	assert.Equal(t, []string{"added.go", "input.txt", "removed.go"}, watch.Changed(previous, current))
+	assert.Empty(t, watch.Changed(current, current))
+}
+
+// TestDiff tests for function Diff
+func TestDiff(t *testing.T) {
+	previous := []output.JSONResult{{Part: "1", Answer: "11"}, {Part: "2", Answer: "31"}}
+	current := []output.JSONResult{{Part: "1", Answer: "11"}, {Part: "2", Answer: "52"}}
+
+	var buf bytes.Buffer
+	assert.NoError(t, watch.Diff(&buf, previous, current))
+	assert.Equal(t, "PART  ANSWER  PREVIOUS   TIME\n"+
+		"1     11      unchanged  0s\n"+
+		"2     52      31         0s\n", buf.String())
+
+	buf.Reset()
+	assert.NoError(t, watch.Diff(&buf, nil, []output.JSONResult{{Part: "1", Error: "boom"}}))
+	assert.Contains(t, buf.String(), "ERROR: boom")
+}
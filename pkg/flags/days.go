@@ -0,0 +1,78 @@
+package flags
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"aoc2024/pkg/registry"
+)
+
+// AllDays selects every registered day
+const AllDays = "all"
+
+// Days is a flag selecting days, either all days or a comma separated list of days and ranges e.g. 1-5,8
+type Days struct {
+	All  bool  // All is set when every registered day is selected
+	List []int // List is the selected days in order without duplicates
+	raw  string
+}
+
+// String returns the selection as given on the command line
+func (d *Days) String() string {
+	return d.raw
+}
+
+// Set parses the selection, every day must be an Advent of Code day
+func (d *Days) Set(selection string) error {
+	selection = strings.TrimSpace(selection)
+	if selection == AllDays {
+		*d = Days{All: true, raw: selection}
+		return nil
+	}
+	if selection == "" {
+		return fmt.Errorf("no day selected")
+	}
+
+	selected := map[int]bool{}
+	for _, field := range strings.Split(selection, ",") {
+		first, last, isRange := strings.Cut(strings.TrimSpace(field), "-")
+
+		from, err := strconv.Atoi(first)
+		if err != nil {
+			return fmt.Errorf("invalid day %q", first)
+		}
+		to := from
+		if isRange {
+			to, err = strconv.Atoi(last)
+			if err != nil {
+				return fmt.Errorf("invalid day %q", last)
+			}
+		}
+		if from > to {
+			return fmt.Errorf("invalid day range %q", field)
+		}
+		if from < 1 || to > registry.MaxDay {
+			return fmt.Errorf("days must be between 1 and %d, got %q", registry.MaxDay, field)
+		}
+
+		for day := from; day <= to; day++ {
+			selected[day] = true
+		}
+	}
+
+	days := make([]int, 0, len(selected))
+	for day := range selected {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+
+	*d = Days{List: days, raw: selection}
+	return nil
+}
+
+// IsZero reports whether no day is selected
+func (d *Days) IsZero() bool {
+	return !d.All && len(d.List) == 0
+}
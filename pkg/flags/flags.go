@@ -1,18 +1,453 @@
 package flags
 
-import "flag"
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
 
-type FlagOutputOpts struct {
+	"aoc2024/internal/config"
+	"aoc2024/internal/output"
+	"aoc2024/internal/profile"
+	"aoc2024/pkg/log"
+	"aoc2024/pkg/reader"
+	"aoc2024/pkg/registry"
+)
+
+// Commands of the CLI
+const (
+	Run     = "run"
+	Bench   = "bench"
+	Verify  = "verify"
+	Watch   = "watch"
+	Serve   = "serve"
+	Fetch   = "fetch"
+	Submit  = "submit"
+	List    = "list"
+	New     = "new"
+	Version = "version"
+	Help    = "help"
+)
+
+// ErrHelp is returned when help was requested, the usage has already been written
+var ErrHelp = flag.ErrHelp
+
+var commands = []struct {
+	name, synopsis string
+}{
+	{Run, "Solve the selected days (default command)"},
+	{Bench, "Benchmark the selected days"},
+	{Watch, "Solve a day again whenever its sources or puzzle input change"},
+	{Serve, "Serve the solvers over a local HTTP API"},
+	{Verify, "Verify the answers of the selected days against the expected answers"},
+	{Fetch, "Download the puzzle inputs of the selected days"},
+	{Submit, "Solve a part of a day and submit its answer"},
+	{List, "List the registered days"},
+	{New, "Generate and register a new day package"},
+	{Version, "Print the version"},
+	{Help, "Print this help"},
+}
+
+// FlagInputOpts options selecting days, parts and their puzzle inputs
+type FlagInputOpts struct {
+	Year        int
+	Days        Days
+	Part        Part
+	File        string
+	Inputs      string
+	Timeout     time.Duration
+	Mmap        bool
+	MaxLineSize int
+}
+
+// FlagLogOpts options of the logger
+type FlagLogOpts struct {
+	Debug     bool
+	LogLevel  string
+	LogFormat string
+}
+
+// FlagRunOpts options of the run command
+type FlagRunOpts struct {
+	FlagInputOpts
+	FlagLogOpts
+	Workers int
+	Output  output.Format
+	Profile profile.Options
+}
+
+// FlagVerifyOpts options of the verify command
+type FlagVerifyOpts struct {
+	FlagInputOpts
+	FlagLogOpts
+	Workers int
+	Answers string
+}
+
+// FlagBenchOpts options of the bench command
+type FlagBenchOpts struct {
+	FlagInputOpts
+	Runs    int
+	Profile profile.Options
+}
+
+// FlagWatchOpts options of the watch command
+type FlagWatchOpts struct {
+	FlagInputOpts
+	Interval time.Duration
+	Root     string
+}
+
+// FlagServeOpts options of the serve command
+type FlagServeOpts struct {
+	FlagLogOpts
+	Addr    string
+	Timeout time.Duration
+}
+
+// FlagFetchOpts options of the fetch command
+type FlagFetchOpts struct {
+	Year    int
+	Days    Days
+	File    string
+	Inputs  string
+	Timeout time.Duration
+}
+
+// FlagSubmitOpts options of the submit command
+type FlagSubmitOpts struct {
+	FlagInputOpts
+	FlagLogOpts
+}
+
+// FlagNewOpts options of the new command
+type FlagNewOpts struct {
+	Year  int
 	Day   int
-	File  string
-	Debug bool
+	Title string
+	Root  string
+}
+
+// Split returns the command and its arguments, run is the command when the arguments start with a flag.
+// Help is returned for the help flags and an empty command for no arguments.
+func Split(args []string) (string, []string) {
+	if len(args) == 0 {
+		return "", nil
+	}
+	switch args[0] {
+	case "-h", "-help", "--help":
+		return Help, nil
+	}
+	if strings.HasPrefix(args[0], "-") {
+		return Run, args
+	}
+	return args[0], args[1:]
+}
+
+// Usage writes the usage of the CLI listing every command
+func Usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: aoc <command> [flags]")
+	fmt.Fprintln(w, "\nCommands:")
+	for _, command := range commands {
+		fmt.Fprintf(w, "  %-8s %s\n", command.name, command.synopsis)
+	}
+	fmt.Fprintln(w, "\nRun 'aoc <command> -h' for the flags of a command.")
+}
+
+// newFlagSet returns a flag set of command writing errors and usage to w
+func newFlagSet(command string, w io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(command, flag.ContinueOnError)
+	fs.SetOutput(w)
+	fs.Usage = func() {
+		defined := 0
+		fs.VisitAll(func(*flag.Flag) {
+			defined++
+		})
+		if defined == 0 {
+			fmt.Fprintf(w, "Usage: aoc %s\n", command)
+			return
+		}
+		fmt.Fprintf(w, "Usage: aoc %s [flags]\n\nFlags:\n", command)
+		fs.PrintDefaults()
+	}
+	return fs
 }
 
-func Parse() (opts FlagOutputOpts) {
-	flag.IntVar(&opts.Day, "day", 0, "Select day to run")
-	flag.StringVar(&opts.File, "file", "", "Path to solve puzzle input")
-	flag.BoolVar(&opts.Debug, "debug", false, "log debug")
+// parse parses args with fs and validates the parsed options, a validation error is written together with the usage
+func parse(fs *flag.FlagSet, args []string, validate func() error) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	err := validate()
+	if err == nil && fs.NArg() > 0 {
+		err = fmt.Errorf("unexpected arguments %q", fs.Args())
+	}
+	if err != nil {
+		fmt.Fprintln(fs.Output(), err)
+		fs.Usage()
+	}
+	return err
+}
+
+// inputVars defines the flags selecting days, parts and their puzzle inputs, defaulting to cfg
+func inputVars(fs *flag.FlagSet, opts *FlagInputOpts, cfg config.Config) {
+	fs.IntVar(&opts.Year, "year", cfg.Year, "Select the `year` of the days")
+	fs.Var(&opts.Days, "day", "Select `days`, a day, a list of days and ranges e.g. 1-5,8 or all")
+	fs.Var(&opts.Part, "part", "Select the `part` to solve: 1, 2 or all")
+	fs.StringVar(&opts.File, "file", "", "Path to solve puzzle input, - reads stdin, .gz and .bz2 are decompressed, {day} and {year} are replaced by the day and year")
+	fs.StringVar(&opts.Inputs, "inputs", cfg.Inputs, "Directory of puzzle inputs named YYYY/dayNN.txt, used when -file is omitted")
+	fs.DurationVar(&opts.Timeout, "timeout", cfg.Timeout, "Cut off solving after the timeout e.g. 30s, no timeout when zero")
+	fs.BoolVar(&opts.Mmap, "mmap", false, "Map puzzle input files into memory instead of reading them, for large inputs")
+	fs.IntVar(&opts.MaxLineSize, "max-line-size", reader.DefaultMaxLineSize, "Longest line of a puzzle input in `bytes`")
+}
+
+// logVars defines the flags of the logger, defaulting to cfg
+func logVars(fs *flag.FlagSet, opts *FlagLogOpts, cfg config.Config) {
+	fs.BoolVar(&opts.Debug, "debug", false, "log debug, shorthand for -log-level debug")
+	fs.StringVar(&opts.LogLevel, "log-level", cfg.LogLevel, "Log `level`: debug, info, warn or error")
+	fs.StringVar(&opts.LogFormat, "log-format", cfg.LogFormat, "Log `format`: json or console")
+}
+
+// validateLog checks the logger flags, -debug overrides the level
+func validateLog(opts *FlagLogOpts) error {
+	if opts.Debug {
+		opts.LogLevel = log.DebugLevel.String()
+	}
+	if _, err := log.ParseLevel(opts.LogLevel); err != nil {
+		return fmt.Errorf("flag -log-level: %w", err)
+	}
+	if !log.ValidFormat(opts.LogFormat) {
+		return fmt.Errorf("flag -log-format must be %s or %s, got %q", log.JSONFormat, log.ConsoleFormat, opts.LogFormat)
+	}
+	return nil
+}
+
+// validateWorkers checks that at least one worker solves the days
+func validateWorkers(workers int) error {
+	if workers < 1 {
+		return fmt.Errorf("flag -workers must be at least 1, got %d", workers)
+	}
+	return nil
+}
+
+// profileVars defines the flags writing pprof profiles and an execution trace of the solvers
+func profileVars(fs *flag.FlagSet, opts *profile.Options) {
+	fs.StringVar(&opts.CPU, "cpuprofile", "", "Write a CPU profile to `file`, labeled by day and part")
+	fs.StringVar(&opts.Mem, "memprofile", "", "Write a memory allocation profile to `file`")
+	fs.StringVar(&opts.Block, "blockprofile", "", "Write a goroutine blocking profile to `file`")
+	fs.StringVar(&opts.Trace, "trace", "", "Write an execution trace to `file`")
+}
+
+// validateInput checks that days of a valid year are selected and how their inputs are read
+func validateInput(opts FlagInputOpts) error {
+	if err := validateSelection(opts.Year, opts.Days, opts.Timeout); err != nil {
+		return err
+	}
+	if opts.MaxLineSize < 1 {
+		return fmt.Errorf("flag -max-line-size must be at least 1, got %d", opts.MaxLineSize)
+	}
+	return nil
+}
+
+// validateSelection checks that days of a valid year are selected within a valid timeout
+func validateSelection(year int, days Days, timeout time.Duration) error {
+	if days.IsZero() {
+		return errors.New("flag -day is required")
+	}
+	if year < registry.FirstYear {
+		return fmt.Errorf("flag -year must be %d or later, got %d", registry.FirstYear, year)
+	}
+	if timeout < 0 {
+		return fmt.Errorf("flag -timeout must not be negative, got %s", timeout)
+	}
+	return nil
+}
+
+// ReadOptions returns the options reading the puzzle inputs selected by opts
+func (opts FlagInputOpts) ReadOptions() []reader.OptFunc {
+	return []reader.OptFunc{reader.WithMmap(opts.Mmap), reader.WithMaxLineSize(opts.MaxLineSize)}
+}
+
+// ParseRun parses the arguments of the run command, the flags default to cfg
+func ParseRun(args []string, w io.Writer, cfg config.Config) (opts FlagRunOpts, err error) {
+	var format string
+
+	fs := newFlagSet(Run, w)
+	inputVars(fs, &opts.FlagInputOpts, cfg)
+	logVars(fs, &opts.FlagLogOpts, cfg)
+	profileVars(fs, &opts.Profile)
+	fs.IntVar(&opts.Workers, "workers", cfg.Workers, "Number of days solved concurrently")
+	fs.StringVar(&format, "output", cfg.Output, "Output format of the results: text, json, csv, markdown or junit")
+
+	err = parse(fs, args, func() error {
+		if err := validateInput(opts.FlagInputOpts); err != nil {
+			return err
+		}
+		if err := validateLog(&opts.FlagLogOpts); err != nil {
+			return err
+		}
+		if err := validateWorkers(opts.Workers); err != nil {
+			return err
+		}
+		opts.Output, err = output.ParseFormat(format)
+		return err
+	})
+	return opts, err
+}
+
+// ParseVerify parses the arguments of the verify command, all days are verified by default, the flags default to cfg
+func ParseVerify(args []string, w io.Writer, cfg config.Config) (opts FlagVerifyOpts, err error) {
+	opts.Days = Days{All: true, raw: AllDays}
+
+	fs := newFlagSet(Verify, w)
+	inputVars(fs, &opts.FlagInputOpts, cfg)
+	logVars(fs, &opts.FlagLogOpts, cfg)
+	fs.IntVar(&opts.Workers, "workers", cfg.Workers, "Number of days solved concurrently")
+	fs.StringVar(&opts.Answers, "answers", "", "Path to expected answers of the year, defaults to YYYY/answers.json in the inputs directory")
+
+	err = parse(fs, args, func() error {
+		if err := validateLog(&opts.FlagLogOpts); err != nil {
+			return err
+		}
+		if err := validateWorkers(opts.Workers); err != nil {
+			return err
+		}
+		return validateInput(opts.FlagInputOpts)
+	})
+	return opts, err
+}
+
+// ParseBench parses the arguments of the bench command, the flags default to cfg
+func ParseBench(args []string, w io.Writer, cfg config.Config) (opts FlagBenchOpts, err error) {
+	fs := newFlagSet(Bench, w)
+	inputVars(fs, &opts.FlagInputOpts, cfg)
+	profileVars(fs, &opts.Profile)
+	fs.IntVar(&opts.Runs, "n", 10, "Number of runs")
+
+	err = parse(fs, args, func() error {
+		if opts.Runs < 1 {
+			return fmt.Errorf("flag -n must be at least 1, got %d", opts.Runs)
+		}
+		return validateInput(opts.FlagInputOpts)
+	})
+	return opts, err
+}
+
+// ParseWatch parses the arguments of the watch command, a single day is watched, the flags default to cfg
+func ParseWatch(args []string, w io.Writer, cfg config.Config) (opts FlagWatchOpts, err error) {
+	fs := newFlagSet(Watch, w)
+	inputVars(fs, &opts.FlagInputOpts, cfg)
+	fs.DurationVar(&opts.Interval, "interval", 500*time.Millisecond, "Interval between polls for changes")
+	fs.StringVar(&opts.Root, "root", ".", "Repository root")
+
+	err = parse(fs, args, func() error {
+		// the watched input is polled for changes and solved again, stdin can only be read once
+		if opts.File == "-" {
+			return errors.New("flag -file must be a path, not stdin")
+		}
+		if err := validateInput(opts.FlagInputOpts); err != nil {
+			return err
+		}
+		if opts.Days.All || len(opts.Days.List) != 1 {
+			return fmt.Errorf("flag -day must select a single day, got %s", &opts.Days)
+		}
+		if opts.Interval <= 0 {
+			return fmt.Errorf("flag -interval must be positive, got %s", opts.Interval)
+		}
+		return nil
+	})
+	return opts, err
+}
+
+// ParseServe parses the arguments of the serve command, the flags default to cfg
+func ParseServe(args []string, w io.Writer, cfg config.Config) (opts FlagServeOpts, err error) {
+	fs := newFlagSet(Serve, w)
+	logVars(fs, &opts.FlagLogOpts, cfg)
+	fs.StringVar(&opts.Addr, "addr", "localhost:8080", "Listen `address` of the HTTP API")
+	fs.DurationVar(&opts.Timeout, "timeout", cfg.Timeout, "Cut off solving a request after the timeout e.g. 30s, no timeout when zero")
+
+	err = parse(fs, args, func() error {
+		if opts.Addr == "" {
+			return errors.New("flag -addr is required")
+		}
+		if err := validateLog(&opts.FlagLogOpts); err != nil {
+			return err
+		}
+		if opts.Timeout < 0 {
+			return fmt.Errorf("flag -timeout must not be negative, got %s", opts.Timeout)
+		}
+		return nil
+	})
+	return opts, err
+}
+
+// ParseFetch parses the arguments of the fetch command, the flags default to cfg
+func ParseFetch(args []string, w io.Writer, cfg config.Config) (opts FlagFetchOpts, err error) {
+	fs := newFlagSet(Fetch, w)
+	fs.IntVar(&opts.Year, "year", cfg.Year, "Select the `year` of the days")
+	fs.Var(&opts.Days, "day", "Select `days`, a day, a list of days and ranges e.g. 1-5,8 or all registered days")
+	fs.StringVar(&opts.File, "file", "", "Path to store the puzzle input, {day} and {year} are replaced by the day and year")
+	fs.StringVar(&opts.Inputs, "inputs", cfg.Inputs, "Directory of puzzle inputs named YYYY/dayNN.txt, used when -file is omitted")
+	fs.DurationVar(&opts.Timeout, "timeout", cfg.Timeout, "Cut off downloading after the timeout e.g. 30s, no timeout when zero")
+
+	err = parse(fs, args, func() error {
+		if opts.File == "-" {
+			return errors.New("flag -file must be a path, not stdin")
+		}
+		return validateSelection(opts.Year, opts.Days, opts.Timeout)
+	})
+	return opts, err
+}
+
+// ParseSubmit parses the arguments of the submit command, a single part of a single day is submitted,
+// the flags default to cfg
+func ParseSubmit(args []string, w io.Writer, cfg config.Config) (opts FlagSubmitOpts, err error) {
+	fs := newFlagSet(Submit, w)
+	inputVars(fs, &opts.FlagInputOpts, cfg)
+	logVars(fs, &opts.FlagLogOpts, cfg)
+
+	err = parse(fs, args, func() error {
+		if err := validateInput(opts.FlagInputOpts); err != nil {
+			return err
+		}
+		if opts.Days.All || len(opts.Days.List) != 1 {
+			return fmt.Errorf("flag -day must select a single day, got %s", &opts.Days)
+		}
+		if opts.Part == 0 {
+			return errors.New("flag -part must select part 1 or 2")
+		}
+		return validateLog(&opts.FlagLogOpts)
+	})
+	return opts, err
+}
+
+// ParseNew parses the arguments of the new command
+func ParseNew(args []string, w io.Writer) (opts FlagNewOpts, err error) {
+	fs := newFlagSet(New, w)
+	fs.IntVar(&opts.Year, "year", registry.DefaultYear, "Year of the day")
+	fs.IntVar(&opts.Day, "day", 0, "Day to generate")
+	fs.StringVar(&opts.Title, "title", "", "Title of the puzzle")
+	fs.StringVar(&opts.Root, "root", ".", "Repository root")
+
+	err = parse(fs, args, func() error {
+		if opts.Day < 1 || opts.Day > registry.MaxDay {
+			return fmt.Errorf("flag -day must be between 1 and %d, got %d", registry.MaxDay, opts.Day)
+		}
+		if opts.Year < registry.FirstYear {
+			return fmt.Errorf("flag -year must be %d or later, got %d", registry.FirstYear, opts.Year)
+		}
+		return nil
+	})
+	return opts, err
+}
 
-	flag.Parse()
-	return
+// ParseNoFlags parses the arguments of a command without flags e.g. list and version
+func ParseNoFlags(command string, args []string, w io.Writer) error {
+	fs := newFlagSet(command, w)
+	return parse(fs, args, func() error {
+		return nil
+	})
 }
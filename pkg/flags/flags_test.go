@@ -0,0 +1,261 @@
+package flags_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"aoc2024/internal/config"
+	"aoc2024/internal/output"
+	"aoc2024/internal/profile"
+	"aoc2024/pkg/flags"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDays tests for type Days
+func TestDays(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"Test Days Success": testDaysSuccess,
+		"Test Days Invalid": testDaysInvalid,
+	} {
+		t.Run(scenario, func(t *testing.T) {
+			fn(t)
+		})
+	}
+}
+
+// TestSplit tests for function Split
+func TestSplit(t *testing.T) {
+	for _, test := range []struct {
+		description string
+		args        []string
+		command     string
+		rest        []string
+	}{
+		{
+			description: "No arguments",
+			args:        []string{},
+			command:     "",
+		},
+		{
+			description: "Command with flags",
+			args:        []string{"bench", "-day", "6"},
+			command:     flags.Bench,
+			rest:        []string{"-day", "6"},
+		},
+		{
+			description: "Flags default to run",
+			args:        []string{"-day", "6"},
+			command:     flags.Run,
+			rest:        []string{"-day", "6"},
+		},
+		{
+			description: "Help flag",
+			args:        []string{"-h"},
+			command:     flags.Help,
+		},
+	} {
+		t.Run(test.description, func(t *testing.T) {
+			command, rest := flags.Split(test.args)
+			assert.Equal(t, test.command, command)
+			assert.Equal(t, test.rest, rest)
+		})
+	}
+}
+
+// TestParseRun tests for function ParseRun
+func TestParseRun(t *testing.T) {
+	opts, err := flags.ParseRun([]string{"-day", "1-3", "-file", "-", "-output", "json"}, io.Discard, config.Default())
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, opts.Days.List)
+	assert.Equal(t, "-", opts.File)
+	assert.Equal(t, "inputs", opts.Inputs)
+	assert.Equal(t, output.JSON, opts.Output)
+	assert.Equal(t, flags.Part(0), opts.Part, "Expected all parts by default")
+
+	for _, args := range [][]string{
+		{},
+		{"-day", "99"},
+		{"-day", "1", "-output", "yaml"},
+		{"-day", "1", "-part", "3"},
+		{"-day", "1", "extra"},
+		{"-unknown"},
+	} {
+		_, err := flags.ParseRun(args, io.Discard, config.Default())
+		assert.Error(t, err, "Expected arguments %q to be rejected", args)
+	}
+
+	_, err = flags.ParseRun([]string{"-h"}, io.Discard, config.Default())
+	assert.True(t, errors.Is(err, flags.ErrHelp), "Expected help to be requested")
+}
+
+// TestParseRunConfig tests that the flags of ParseRun default to the config and override it
+func TestParseRunConfig(t *testing.T) {
+	cfg := config.Config{Year: 2023, Inputs: "puzzles", Output: "csv", LogLevel: "warn", LogFormat: "console", Workers: 4, Timeout: time.Minute}
+
+	opts, err := flags.ParseRun([]string{"-day", "1"}, io.Discard, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, 2023, opts.Year)
+	assert.Equal(t, "puzzles", opts.Inputs)
+	assert.Equal(t, output.CSV, opts.Output)
+	assert.Equal(t, flags.FlagLogOpts{LogLevel: "warn", LogFormat: "console"}, opts.FlagLogOpts)
+	assert.Equal(t, 4, opts.Workers)
+	assert.Equal(t, time.Minute, opts.Timeout)
+
+	opts, err = flags.ParseRun([]string{"-day", "1", "-inputs", "other", "-output", "json", "-debug", "-workers", "1"}, io.Discard, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "other", opts.Inputs)
+	assert.Equal(t, output.JSON, opts.Output)
+	assert.Equal(t, "debug", opts.LogLevel, "Expected -debug to override the log level")
+	assert.Equal(t, 1, opts.Workers)
+
+	for _, args := range [][]string{
+		{"-day", "1", "-workers", "0"},
+		{"-day", "1", "-year", "2014"},
+		{"-day", "1", "-log-level", "loud"},
+		{"-day", "1", "-log-format", "xml"},
+	} {
+		_, err := flags.ParseRun(args, io.Discard, cfg)
+		assert.Error(t, err, "Expected arguments %q to be rejected", args)
+	}
+}
+
+// TestParseWatch tests for function ParseWatch
+func TestParseWatch(t *testing.T) {
+	opts, err := flags.ParseWatch([]string{"-day", "6", "-file", "input.txt"}, io.Discard, config.Default())
+	require.NoError(t, err)
+	assert.Equal(t, []int{6}, opts.Days.List)
+	assert.Equal(t, 500*time.Millisecond, opts.Interval)
+
+	for _, args := range [][]string{
+		{"-day", "1-3"},
+		{"-day", "all"},
+		{"-day", "6", "-interval", "0s"},
+		{"-day", "6", "-file", "-"},
+	} {
+		_, err := flags.ParseWatch(args, io.Discard, config.Default())
+		assert.Error(t, err, "Expected %q to be rejected", args)
+	}
+}
+
+// TestParseBench tests for function ParseBench
+func TestParseBench(t *testing.T) {
+	opts, err := flags.ParseBench([]string{"-day", "6", "-n", "50", "-part", "2"}, io.Discard, config.Default())
+	require.NoError(t, err)
+	assert.Equal(t, []int{6}, opts.Days.List)
+	assert.Equal(t, flags.Part(2), opts.Part)
+	assert.Equal(t, 50, opts.Runs)
+	assert.Equal(t, profile.Options{}, opts.Profile)
+
+	opts, err = flags.ParseBench([]string{"-day", "6", "-cpuprofile", "cpu.out", "-trace", "trace.out"}, io.Discard, config.Default())
+	require.NoError(t, err)
+	assert.Equal(t, profile.Options{CPU: "cpu.out", Trace: "trace.out"}, opts.Profile)
+
+	_, err = flags.ParseBench([]string{"-day", "6", "-n", "0"}, io.Discard, config.Default())
+	assert.Error(t, err, "Expected zero runs to be rejected")
+}
+
+// TestParseVerify tests for function ParseVerify
+func TestParseVerify(t *testing.T) {
+	opts, err := flags.ParseVerify([]string{}, io.Discard, config.Default())
+	require.NoError(t, err)
+	assert.True(t, opts.Days.All, "Expected all days to be verified by default")
+	assert.Empty(t, opts.Answers, "Expected the answers file of the year in the inputs directory by default")
+}
+
+// TestParseFetch tests for function ParseFetch
+func TestParseFetch(t *testing.T) {
+	opts, err := flags.ParseFetch([]string{"-day", "1-3", "-year", "2023"}, io.Discard, config.Default())
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, opts.Days.List)
+	assert.Equal(t, 2023, opts.Year)
+	assert.Equal(t, "inputs", opts.Inputs)
+
+	for _, args := range [][]string{
+		{},
+		{"-day", "3", "-file", "-"},
+		{"-day", "3", "-part", "1"},
+	} {
+		_, err := flags.ParseFetch(args, io.Discard, config.Default())
+		assert.Error(t, err, "Expected %q to be rejected", args)
+	}
+}
+
+// TestParseSubmit tests for function ParseSubmit
+func TestParseSubmit(t *testing.T) {
+	opts, err := flags.ParseSubmit([]string{"-day", "3", "-part", "2"}, io.Discard, config.Default())
+	require.NoError(t, err)
+	assert.Equal(t, []int{3}, opts.Days.List)
+	assert.Equal(t, flags.Part(2), opts.Part)
+
+	for _, args := range [][]string{
+		{"-day", "3"},
+		{"-day", "3", "-part", "all"},
+		{"-day", "1-3", "-part", "1"},
+		{"-day", "all", "-part", "1"},
+	} {
+		_, err := flags.ParseSubmit(args, io.Discard, config.Default())
+		assert.Error(t, err, "Expected %q to be rejected", args)
+	}
+}
+
+// TestParseNew tests for function ParseNew
+func TestParseNew(t *testing.T) {
+	opts, err := flags.ParseNew([]string{"-day", "9", "-title", "Disk Fragmenter"}, io.Discard)
+	require.NoError(t, err)
+	assert.Equal(t, 9, opts.Day)
+	assert.Equal(t, "Disk Fragmenter", opts.Title)
+
+	_, err = flags.ParseNew([]string{}, io.Discard)
+	assert.Error(t, err, "Expected missing day to be rejected")
+}
+
+func testDaysSuccess(t *testing.T) {
+	for _, test := range []struct {
+		description string
+		selection   string
+		expected    []int
+	}{
+		{
+			description: "Single day",
+			selection:   "3",
+			expected:    []int{3},
+		},
+		{
+			description: "Range and list",
+			selection:   "1-3,8",
+			expected:    []int{1, 2, 3, 8},
+		},
+		{
+			description: "Unordered with duplicates",
+			selection:   "5, 2-3,3",
+			expected:    []int{2, 3, 5},
+		},
+	} {
+		t.Run(test.description, func(t *testing.T) {
+			days := flags.Days{}
+			require.NoError(t, days.Set(test.selection))
+			assert.Equal(t, test.expected, days.List)
+			assert.False(t, days.All)
+		})
+	}
+
+	t.Run("All days", func(t *testing.T) {
+		days := flags.Days{}
+		require.NoError(t, days.Set("all"))
+		assert.True(t, days.All)
+		assert.Equal(t, "all", days.String())
+	})
+}
+
+func testDaysInvalid(t *testing.T) {
+	for _, selection := range []string{"", "one", "3-", "5-2", "0", "1-26"} {
+		t.Run(selection, func(t *testing.T) {
+			days := flags.Days{}
+			assert.Error(t, days.Set(selection), "Expected selection %q to be rejected", selection)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package flags
+
+import (
+	"strconv"
+
+	"aoc2024/internal/run"
+)
+
+// AllParts selects every implemented part
+const AllParts = run.AllPartsName
+
+// Part is a flag selecting a single part or all parts, the zero value selects all parts
+type Part int
+
+// String returns the selected part or all
+func (p *Part) String() string {
+	if *p == run.AllParts {
+		return AllParts
+	}
+	return strconv.Itoa(int(*p))
+}
+
+// Set parses the part, either 1, 2 or all
+func (p *Part) Set(s string) error {
+	part, err := run.ParsePart(s)
+	if err != nil {
+		return err
+	}
+	*p = Part(part)
+	return nil
+}
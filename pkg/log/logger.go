@@ -8,11 +8,8 @@ import (
 	"go.uber.org/zap/zaptest/observer"
 )
 
-var (
-	// Logger is the global variable for logging
-	Logger *zap.SugaredLogger
-	DEBUG  = os.Getenv("DEBUG") // Set DEBUG to true
-)
+// Logger is the global variable for logging
+var Logger *zap.SugaredLogger
 
 const (
 	SamplingConfigInitial    = 100
@@ -22,12 +19,16 @@ const (
 	WarningLevel = zap.WarnLevel
 	InfoLevel    = zap.InfoLevel
 	DebugLevel   = zap.DebugLevel
+	// Log Formats
+	JSONFormat    = "json"
+	ConsoleFormat = "console"
 )
 
 // Options Logger options
 type Options struct {
-	Level zapcore.Level
-	Core  *zapcore.Core
+	Level  zapcore.Level
+	Format string
+	Core   *zapcore.Core
 }
 
 // OptFunc used for configuring to set NewLogger Options
@@ -36,7 +37,8 @@ type OptFunc func(*Options)
 // defaultOpts Set default for struct Options
 func defaultOpts() Options {
 	return Options{
-		Level: InfoLevel,
+		Level:  InfoLevel,
+		Format: JSONFormat,
 	}
 }
 
@@ -54,15 +56,27 @@ func WithLevel(level zapcore.Level) OptFunc {
 	}
 }
 
+// WithFormat set options format, JSONFormat or ConsoleFormat
+func WithFormat(format string) OptFunc {
+	return func(o *Options) {
+		o.Format = format
+	}
+}
+
+// ParseLevel parses a level name e.g. debug or info
+func ParseLevel(level string) (zapcore.Level, error) {
+	return zapcore.ParseLevel(level)
+}
+
+// ValidFormat reports whether format is a known log format
+func ValidFormat(format string) bool {
+	return format == JSONFormat || format == ConsoleFormat
+}
+
 // init a go internal function that runs once package log is imported; the more you know ;)
 func init() {
-	// Set the default logger
-	Logger = NewLogger(func(o *Options) {
-		// If environmental variable DEBUG equal true then log at debug level
-		if DEBUG == "true" {
-			o.Level = DebugLevel
-		}
-	})
+	// Set the default logger, the CLI configures it from its config
+	Logger = NewLogger()
 }
 
 // InitializeLogger initialize the Logger variable
@@ -70,6 +84,11 @@ func InitializeLogger(optFns ...OptFunc) {
 	Logger = NewLogger(optFns...)
 }
 
+// Disable replaces the Logger with a no-op logger, nothing will be logged
+func Disable() {
+	InitializeLogger(WithCore(zapcore.NewNopCore()))
+}
+
 // NewLogger create a new logger
 func NewLogger(optFns ...OptFunc) *zap.SugaredLogger {
 	options := defaultOpts()
@@ -86,9 +105,15 @@ func NewLogger(optFns ...OptFunc) *zap.SugaredLogger {
 	atom := zap.NewAtomicLevelAt(options.Level)
 	encoderConfig := zap.NewProductionEncoderConfig()
 
+	encoder := zapcore.NewJSONEncoder(encoderConfig)
+	if options.Format == ConsoleFormat {
+		encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+		encoder = zapcore.NewConsoleEncoder(encoderConfig)
+	}
+
 	logger := zap.New(zapcore.NewCore(
-		zapcore.NewJSONEncoder(encoderConfig),
-		zapcore.Lock(os.Stdout),
+		encoder,
+		zapcore.Lock(os.Stderr), // Keep stdout for results
 		atom,
 	))
 
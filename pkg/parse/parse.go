@@ -0,0 +1,369 @@
+// Package parse is a small parser combinator library for puzzle inputs shaped by a grammar rather than lines.
+// Parsers track the offset in the input, the line and column of a position are computed when needed.
+// Failures are reader.ParseError at the furthest position reached.
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"aoc2024/pkg/reader"
+)
+
+// Pos is a position in the text, Line and Column start at 1 and Column counts bytes
+type Pos struct {
+	Offset int
+	Line   int
+	Column int
+}
+
+// String returns the position as line:column
+func (p Pos) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// Input is the text left to parse at its offset, inputs are made by Parse
+type Input struct {
+	offset int
+	st     *state
+}
+
+// state of a Parse shared by every Input, it keeps the failure reached furthest
+type state struct {
+	text  string
+	lines []int // lines holds the offset of every line start, indexed on first use
+	fail  int
+	err   error
+}
+
+// errFailed is returned by failing parsers, Parse turns it into the ParseError reached furthest.
+// Failing is common e.g. in Alt and Scan so it must not allocate.
+var errFailed = errors.New("parse failed")
+
+// pos returns the position of offset, lines are only indexed once a position is needed
+func (st *state) pos(offset int) Pos {
+	if st.lines == nil {
+		st.lines = []int{0}
+		for i := 0; i < len(st.text); i++ {
+			if st.text[i] == '\n' {
+				st.lines = append(st.lines, i+1)
+			}
+		}
+	}
+	// the last line starting at or before offset
+	line := sort.SearchInts(st.lines, offset+1)
+	return Pos{Offset: offset, Line: line, Column: offset - st.lines[line-1] + 1}
+}
+
+// Pos returns the position of the input
+func (in Input) Pos() Pos {
+	if in.st == nil {
+		return Pos{Line: 1, Column: 1}
+	}
+	return in.st.pos(in.offset)
+}
+
+// Rest returns the text left to parse
+func (in Input) Rest() string {
+	if in.st == nil {
+		return ""
+	}
+	return in.st.text[in.offset:]
+}
+
+// advance returns the input after the next n bytes
+func (in Input) advance(n int) Input {
+	in.offset += n
+	return in
+}
+
+// skip returns the input after the next rune
+func (in Input) skip() Input {
+	if b := in.st.text[in.offset]; b < utf8.RuneSelf {
+		return in.advance(1)
+	}
+	_, size := utf8.DecodeRuneInString(in.Rest())
+	return in.advance(size)
+}
+
+// errorf fails with an error formatted according to format at the position of the input
+func (in Input) errorf(format string, args ...any) error {
+	return in.fail(fmt.Errorf(format, args...))
+}
+
+// fail records err at the offset of the input when it is the furthest failure and returns errFailed,
+// parsers build err once so failing doesn't allocate
+func (in Input) fail(err error) error {
+	if in.st == nil {
+		return in.parseError(err)
+	}
+	if in.st.err == nil || in.offset > in.st.fail {
+		in.st.fail, in.st.err = in.offset, err
+	}
+	return errFailed
+}
+
+// parseError returns err at the position of the input
+func (in Input) parseError(err error) error {
+	pos := in.Pos()
+	return &reader.ParseError{Line: pos.Line, Column: pos.Column, Err: err}
+}
+
+// Parser parses a T at the start of the input and returns the input left after it
+type Parser[T any] func(in Input) (T, Input, error)
+
+// Located is a parsed value with the position it starts at
+type Located[T any] struct {
+	Pos   Pos
+	Value T
+}
+
+// Parse parses the whole text with p, text left after p is an error. A failure of p is
+// reported as the ParseError reached furthest.
+func Parse[T any](p Parser[T], text string) (T, error) {
+	st := &state{text: text}
+	v, rest, err := p(Input{st: st})
+	if errors.Is(err, errFailed) && st.err != nil {
+		return v, Input{offset: st.fail, st: st}.parseError(st.err)
+	}
+	if err != nil {
+		return v, err
+	}
+	if left := rest.Rest(); left != "" {
+		if len(left) > 10 {
+			left = left[:10] + "..."
+		}
+		return v, rest.parseError(fmt.Errorf("unexpected %q", left))
+	}
+	return v, nil
+}
+
+// Literal parses the text s
+func Literal(s string) Parser[string] {
+	expected := fmt.Errorf("expected %q", s)
+	return func(in Input) (string, Input, error) {
+		if !strings.HasPrefix(in.Rest(), s) {
+			return "", in, in.fail(expected)
+		}
+		return s, in.advance(len(s)), nil
+	}
+}
+
+// Rune parses any single rune
+func Rune() Parser[rune] {
+	return func(in Input) (rune, Input, error) {
+		r, size := utf8.DecodeRuneInString(in.Rest())
+		if size == 0 {
+			return 0, in, in.errorf("unexpected end of input")
+		}
+		return r, in.advance(size), nil
+	}
+}
+
+// Digits parses an unsigned decimal number of minDigits to maxDigits digits
+func Digits(minDigits, maxDigits int) Parser[int] {
+	var (
+		expected = fmt.Errorf("expected %d to %d digits", minDigits, maxDigits)
+		tooLong  = fmt.Errorf("number has more than %d digits", maxDigits)
+	)
+	return func(in Input) (int, Input, error) {
+		rest := in.Rest()
+		n := 0
+		for n < len(rest) && n < maxDigits && rest[n] >= '0' && rest[n] <= '9' {
+			n++
+		}
+		if n < minDigits {
+			return 0, in, in.fail(expected)
+		}
+		if n < len(rest) && rest[n] >= '0' && rest[n] <= '9' {
+			return 0, in, in.fail(tooLong)
+		}
+		v, err := strconv.Atoi(rest[:n])
+		if err != nil {
+			return 0, in, in.errorf("invalid number %q", rest[:n])
+		}
+		return v, in.advance(n), nil
+	}
+}
+
+// errInteger is the failure of Int
+var errInteger = errors.New("expected integer")
+
+// Int parses a decimal integer with an optional minus sign
+func Int() Parser[int] {
+	return func(in Input) (int, Input, error) {
+		rest := in.Rest()
+		n := 0
+		if n < len(rest) && rest[n] == '-' {
+			n++
+		}
+		start := n
+		for n < len(rest) && rest[n] >= '0' && rest[n] <= '9' {
+			n++
+		}
+		if n == start {
+			return 0, in, in.fail(errInteger)
+		}
+		v, err := strconv.Atoi(rest[:n])
+		if err != nil {
+			return 0, in, in.errorf("invalid integer %q", rest[:n])
+		}
+		return v, in.advance(n), nil
+	}
+}
+
+// Map parses with p and converts its value with f
+func Map[T, U any](p Parser[T], f func(T) U) Parser[U] {
+	return func(in Input) (U, Input, error) {
+		v, rest, err := p(in)
+		if err != nil {
+			var zero U
+			return zero, in, err
+		}
+		return f(v), rest, nil
+	}
+}
+
+// Seq2 parses with a then b and combines their values with f
+func Seq2[A, B, R any](a Parser[A], b Parser[B], f func(A, B) R) Parser[R] {
+	return func(in Input) (R, Input, error) {
+		var zero R
+		va, rest, err := a(in)
+		if err != nil {
+			return zero, in, err
+		}
+		vb, rest, err := b(rest)
+		if err != nil {
+			return zero, in, err
+		}
+		return f(va, vb), rest, nil
+	}
+}
+
+// Seq3 parses with a, b then c and combines their values with f
+func Seq3[A, B, C, R any](a Parser[A], b Parser[B], c Parser[C], f func(A, B, C) R) Parser[R] {
+	return Seq2(Seq2(a, b, func(va A, vb B) func(C) R {
+		return func(vc C) R {
+			return f(va, vb, vc)
+		}
+	}), c, func(g func(C) R, vc C) R {
+		return g(vc)
+	})
+}
+
+// Between parses p enclosed by opening and closing
+func Between[O, T, C any](opening Parser[O], p Parser[T], closing Parser[C]) Parser[T] {
+	return Seq3(opening, p, closing, func(_ O, v T, _ C) T {
+		return v
+	})
+}
+
+// errNoAlternative is the failure of Alt without parsers
+var errNoAlternative = errors.New("no alternative")
+
+// Alt parses with the first of parsers that succeeds, Parse reports the failure reached furthest when all fail
+func Alt[T any](parsers ...Parser[T]) Parser[T] {
+	return func(in Input) (T, Input, error) {
+		var zero T
+		if len(parsers) == 0 {
+			return zero, in, in.fail(errNoAlternative)
+		}
+
+		var failed error
+		for _, p := range parsers {
+			v, rest, err := p(in)
+			if err == nil {
+				return v, rest, nil
+			}
+			// keep an error of a parser outside this package, errFailed is recorded already
+			if failed == nil || failed == errFailed { //nolint:errorlint // errFailed is never wrapped, this is the hot path
+				failed = err
+			}
+		}
+		return zero, in, failed
+	}
+}
+
+// Many parses with p zero or more times until it fails
+func Many[T any](p Parser[T]) Parser[[]T] {
+	return func(in Input) ([]T, Input, error) {
+		values := []T{}
+		for {
+			v, rest, err := p(in)
+			if err != nil || rest.offset == in.offset {
+				return values, in, nil
+			}
+			values = append(values, v)
+			in = rest
+		}
+	}
+}
+
+// SepBy parses with p zero or more times separated by sep
+func SepBy[T, S any](p Parser[T], sep Parser[S]) Parser[[]T] {
+	return func(in Input) ([]T, Input, error) {
+		first, rest, err := p(in)
+		if err != nil {
+			return []T{}, in, nil
+		}
+		others, rest, _ := Many(Seq2(sep, p, func(_ S, v T) T {
+			return v
+		}))(rest)
+		return append([]T{first}, others...), rest, nil
+	}
+}
+
+// Locate parses with p and records the position its value starts at
+func Locate[T any](p Parser[T]) Parser[Located[T]] {
+	return func(in Input) (Located[T], Input, error) {
+		v, rest, err := p(in)
+		if err != nil {
+			return Located[T]{}, in, err
+		}
+		return Located[T]{Pos: in.Pos(), Value: v}, rest, nil
+	}
+}
+
+// Scan finds every match of p in the input, skipping a rune wherever p fails, e.g. in corrupted memory
+func Scan[T any](p Parser[T]) Parser[[]Located[T]] {
+	return scan(p, nil)
+}
+
+// ScanAt is like Scan but only tries p where the text starts with a rune of first, skipping to them is
+// much faster than trying p at every rune of a long input
+func ScanAt[T any](first string, p Parser[T]) Parser[[]Located[T]] {
+	return scan(p, func(in Input) Input {
+		i := strings.IndexAny(in.Rest(), first)
+		if i < 0 {
+			return in.advance(len(in.Rest()))
+		}
+		return in.advance(i)
+	})
+}
+
+// scan finds every match of p, seek moves the input to where p is tried next, every rune when nil
+func scan[T any](p Parser[T], seek func(Input) Input) Parser[[]Located[T]] {
+	located := Locate(p)
+	return func(in Input) ([]Located[T], Input, error) {
+		values := []Located[T]{}
+		for {
+			if seek != nil {
+				in = seek(in)
+			}
+			if in.Rest() == "" {
+				return values, in, nil
+			}
+			v, rest, err := located(in)
+			if err != nil || rest.offset == in.offset {
+				in = in.skip()
+				continue
+			}
+			values = append(values, v)
+			in = rest
+		}
+	}
+}
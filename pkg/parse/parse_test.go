@@ -0,0 +1,102 @@
+package parse_test
+
+import (
+	"testing"
+
+	"aoc2024/pkg/parse"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// point parses x,y
+var point = parse.Seq3(parse.Int(), parse.Literal(","), parse.Int(), func(x int, _ string, y int) [2]int {
+	return [2]int{x, y}
+})
+
+// TestParse tests the combinators on a grammar of points e.g. (1,2);(-3,4)
+func TestParse(t *testing.T) {
+	points := parse.SepBy(parse.Between(parse.Literal("("), point, parse.Literal(")")), parse.Literal(";"))
+
+	v, err := parse.Parse(points, "(1,2);(-3,4)")
+	require.NoError(t, err)
+	assert.Equal(t, [][2]int{{1, 2}, {-3, 4}}, v)
+
+	v, err = parse.Parse(points, "")
+	require.NoError(t, err)
+	assert.Empty(t, v)
+
+	scenarios := map[string]struct {
+		text     string
+		expected string
+	}{
+		"trailing text":  {text: "(1,2);(3,4)x", expected: `line 1 column 12: unexpected "x"`},
+		"missing number": {text: "(1,)", expected: `line 1 column 1: unexpected "(1,)"`},
+		"second line":    {text: "(1,2);\n(3,4)", expected: `line 1 column 6: unexpected ";\n(3,4)"`},
+	}
+	for name, s := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			_, err := parse.Parse(points, s.text)
+			assert.EqualError(t, err, s.expected)
+		})
+	}
+
+	lines := parse.Seq3(point, parse.Literal("\n"), point, func(a [2]int, _ string, b [2]int) [][2]int {
+		return [][2]int{a, b}
+	})
+	_, err = parse.Parse(lines, "1,2\n3,x")
+	assert.EqualError(t, err, "line 2 column 3: expected integer", "Expected position on the second line")
+}
+
+// TestAlt tests that function Alt returns the error reached furthest
+func TestAlt(t *testing.T) {
+	alt := parse.Alt(
+		parse.Map(parse.Literal("do()"), func(string) [2]int { return [2]int{} }),
+		parse.Between(parse.Literal("mul("), point, parse.Literal(")")),
+	)
+
+	_, err := parse.Parse(alt, "mul(1,2]")
+	assert.EqualError(t, err, `line 1 column 8: expected ")"`)
+
+	v, err := parse.Parse(parse.Many(alt), "do()mul(3,4)")
+	require.NoError(t, err)
+	assert.Equal(t, [][2]int{{0, 0}, {3, 4}}, v)
+}
+
+// TestDigits tests for function Digits
+func TestDigits(t *testing.T) {
+	v, err := parse.Parse(parse.Digits(1, 3), "123")
+	require.NoError(t, err)
+	assert.Equal(t, 123, v)
+
+	_, err = parse.Parse(parse.Digits(1, 3), "1234")
+	assert.EqualError(t, err, "line 1 column 1: number has more than 3 digits")
+	_, err = parse.Parse(parse.Digits(1, 3), "-1")
+	assert.Error(t, err, "Expected sign to be rejected")
+	_, err = parse.Parse(parse.Int(), "99999999999999999999")
+	assert.Error(t, err, "Expected overflow to be rejected")
+}
+
+// TestScan tests that function Scan finds every match with its position
+func TestScan(t *testing.T) {
+	v, err := parse.Parse(parse.Scan(parse.Between(parse.Literal("mul("), point, parse.Literal(")"))), "xmul(2,4)%&\nmul[3,7]mul(5,5)")
+	require.NoError(t, err)
+	assert.Equal(t, []parse.Located[[2]int]{
+		{Pos: parse.Pos{Offset: 1, Line: 1, Column: 2}, Value: [2]int{2, 4}},
+		{Pos: parse.Pos{Offset: 20, Line: 2, Column: 9}, Value: [2]int{5, 5}},
+	}, v)
+}
+
+// TestScanAt tests that function ScanAt finds the same matches as Scan
+func TestScanAt(t *testing.T) {
+	mul := parse.Between(parse.Literal("mul("), point, parse.Literal(")"))
+	text := "xmul(2,4)%&\nmumul[3,7]mul(5,5)é\nmul(1,1)"
+
+	expected, err := parse.Parse(parse.Scan(mul), text)
+	require.NoError(t, err)
+	v, err := parse.Parse(parse.ScanAt("m", mul), text)
+	require.NoError(t, err)
+	assert.Equal(t, expected, v)
+	assert.Len(t, v, 3)
+	assert.Equal(t, parse.Pos{Offset: 33, Line: 3, Column: 1}, v[2].Pos)
+}
@@ -0,0 +1,65 @@
+package reader
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"io"
+	"path/filepath"
+)
+
+// Compression of a puzzle input
+type Compression string
+
+const (
+	NoCompression Compression = ""
+	Gzip          Compression = ".gz"
+	Bzip2         Compression = ".bz2"
+)
+
+var (
+	gzipMagic  = []byte{0x1f, 0x8b}
+	bzip2Magic = []byte("BZh")
+)
+
+// CompressionOf returns the compression of filename by its extension
+func CompressionOf(filename string) Compression {
+	switch ext := Compression(filepath.Ext(filename)); ext {
+	case Gzip, Bzip2:
+		return ext
+	}
+	return NoCompression
+}
+
+// Sniff returns the compression of r by its magic bytes and a reader of the whole content of r
+func Sniff(r io.Reader) (Compression, io.Reader, error) {
+	buffered := bufio.NewReader(r)
+	magic, err := buffered.Peek(len(bzip2Magic))
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return NoCompression, buffered, err
+	}
+
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		return Gzip, buffered, nil
+	case bytes.HasPrefix(magic, bzip2Magic):
+		return Bzip2, buffered, nil
+	}
+	return NoCompression, buffered, nil
+}
+
+// Decompress returns the decompressed content of r and the function releasing the decompressor
+func Decompress(r io.Reader, compression Compression) (io.Reader, func() error, error) {
+	switch compression {
+	case Gzip:
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		return gz, gz.Close, nil
+	case Bzip2:
+		return bzip2.NewReader(r), func() error { return nil }, nil
+	}
+	return r, func() error { return nil }, nil
+}
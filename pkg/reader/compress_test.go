@@ -0,0 +1,96 @@
+package reader_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"aoc2024/pkg/reader"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bzip2Example is "3   4\n4   3\n" compressed with bzip2, the standard library only decompresses bzip2
+var bzip2Example = []byte{
+	0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0x28, 0x5b, 0x10, 0x62, 0x00, 0x00,
+	0x04, 0xd8, 0x00, 0x00, 0x10, 0x40, 0x00, 0x0c, 0x00, 0x20, 0x00, 0x21, 0x9a, 0x68, 0x33, 0x4d,
+	0x33, 0x06, 0xc4, 0x38, 0xbb, 0x92, 0x29, 0xc2, 0x84, 0x81, 0x42, 0xd8, 0x83, 0x10,
+}
+
+// gzipped returns content compressed with gzip
+func gzipped(t *testing.T, content string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+// TestOpenCompressed tests that function Open decompresses files by their extension
+func TestOpenCompressed(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"day01.txt.gz":  gzipped(t, "3   4\n4   3\n"),
+		"day01.txt.bz2": bzip2Example,
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			file := filepath.Join(dir, name)
+			require.NoError(t, os.WriteFile(file, content, 0o600))
+
+			f, err := reader.Open(file, reader.WithMmap(true))
+			require.NoError(t, err)
+			defer f.Close()
+
+			fields, err := reader.NewInput(f).IntFields()
+			require.NoError(t, err)
+			assert.Equal(t, [][]int{{3, 4}, {4, 3}}, fields)
+		})
+	}
+
+	corrupted := filepath.Join(dir, "corrupted.txt.gz")
+	require.NoError(t, os.WriteFile(corrupted, []byte("3   4\n"), 0o600))
+	_, err := reader.Open(corrupted)
+	assert.Error(t, err, "Expected file without gzip header to be rejected")
+}
+
+// TestSniff tests that function Sniff detects the compression by the magic bytes and keeps the whole content
+func TestSniff(t *testing.T) {
+	scenarios := map[string]struct {
+		content  []byte
+		expected reader.Compression
+	}{
+		"gzip":  {content: gzipped(t, "3   4\n"), expected: reader.Gzip},
+		"bzip2": {content: bzip2Example, expected: reader.Bzip2},
+		"plain": {content: []byte("3   4\n"), expected: reader.NoCompression},
+		"short": {content: []byte("3"), expected: reader.NoCompression},
+		"empty": {content: nil, expected: reader.NoCompression},
+	}
+
+	for name, s := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			compression, r, err := reader.Sniff(bytes.NewReader(s.content))
+			require.NoError(t, err)
+			assert.Equal(t, s.expected, compression)
+
+			content, _, err := reader.Decompress(r, compression)
+			require.NoError(t, err)
+			decompressed, err := io.ReadAll(content)
+			require.NoError(t, err)
+			if s.expected == reader.NoCompression {
+				assert.Equal(t, string(s.content), string(decompressed), "Expected the peeked bytes to be kept")
+			} else {
+				assert.True(t, strings.HasPrefix(string(decompressed), "3   4\n"))
+			}
+		})
+	}
+}
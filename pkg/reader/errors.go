@@ -0,0 +1,44 @@
+package reader
+
+import (
+	"fmt"
+)
+
+// ParseError is an error in the puzzle input at a position, Line and Column start at 1.
+// A Column of 0 means the whole line, File is empty when the input isn't read from a file.
+type ParseError struct {
+	File   string
+	Line   int
+	Column int
+	Err    error
+}
+
+// Errorf returns a ParseError at line and column formatted according to format
+func Errorf(line, column int, format string, args ...any) *ParseError {
+	return &ParseError{
+		Line:   line,
+		Column: column,
+		Err:    fmt.Errorf(format, args...),
+	}
+}
+
+// Error returns the position and the error e.g. "day07.txt:3:5: invalid number"
+func (e *ParseError) Error() string {
+	position := fmt.Sprintf("line %d", e.Line)
+	if e.File != "" {
+		position = fmt.Sprintf("%s:%d", e.File, e.Line)
+	}
+	if e.Column > 0 {
+		if e.File != "" {
+			position = fmt.Sprintf("%s:%d", position, e.Column)
+		} else {
+			position = fmt.Sprintf("%s column %d", position, e.Column)
+		}
+	}
+	return position + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
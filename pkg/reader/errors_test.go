@@ -0,0 +1,45 @@
+package reader_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"aoc2024/pkg/reader"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseError(t *testing.T) {
+	scenarios := map[string]struct {
+		err      *reader.ParseError
+		expected string
+	}{
+		"line": {
+			err:      reader.Errorf(2, 0, "missing %q", ":"),
+			expected: `line 2: missing ":"`,
+		},
+		"column": {
+			err:      reader.Errorf(3, 5, "invalid number"),
+			expected: "line 3 column 5: invalid number",
+		},
+		"file": {
+			err:      &reader.ParseError{File: "day07.txt", Line: 3, Column: 5, Err: errors.New("invalid number")},
+			expected: "day07.txt:3:5: invalid number",
+		},
+		"file line": {
+			err:      &reader.ParseError{File: "day07.txt", Line: 3, Err: errors.New("invalid number")},
+			expected: "day07.txt:3: invalid number",
+		},
+	}
+
+	for name, s := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			assert.EqualError(t, s.err, s.expected)
+		})
+	}
+
+	_, err := strconv.Atoi("x")
+	wrapped := &reader.ParseError{Line: 1, Err: err}
+	assert.ErrorIs(t, wrapped, strconv.ErrSyntax)
+}
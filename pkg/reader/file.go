@@ -0,0 +1,71 @@
+package reader
+
+import (
+	"bytes"
+	"io"
+	"os"
+)
+
+// File is an opened puzzle input carrying its read options to NewInput. The content of a memory mapped
+// file or of FromBytes is held in memory and split into lines without copying.
+type File struct {
+	r     io.Reader
+	data  []byte
+	close func() error
+	opts  Options
+}
+
+// FromBytes returns the File of data held in memory, data must not be modified while its lines are in use
+func FromBytes(data []byte, opts ...OptFunc) *File {
+	return &File{
+		r:     bytes.NewReader(data),
+		data:  data,
+		close: func() error { return nil },
+		opts:  newOptions(opts...),
+	}
+}
+
+// Read reads the content of the file
+func (f *File) Read(p []byte) (int, error) {
+	return f.r.Read(p)
+}
+
+// Close closes the file, lines of a memory mapped file must not be used after Close
+func (f *File) Close() error {
+	return f.close()
+}
+
+// Bytes returns the content of a file held in memory, nil when the file is read as a stream
+func (f *File) Bytes() []byte {
+	return f.data
+}
+
+// mapFile maps the regular file into memory, other files e.g. pipes are read as a stream
+func mapFile(file *os.File, o Options) (*File, error) {
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return &File{r: file, close: file.Close, opts: o}, nil
+	}
+
+	data, unmap, err := mmap(file, int(info.Size()))
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &File{
+		r:    bytes.NewReader(data),
+		data: data,
+		close: func() error {
+			err := unmap()
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			return err
+		},
+		opts: o,
+	}, nil
+}
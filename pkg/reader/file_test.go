@@ -0,0 +1,79 @@
+package reader_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"aoc2024/pkg/reader"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOpen tests that streamed, memory mapped and in memory files have the same lines
+func TestOpen(t *testing.T) {
+	content := "47|53\r\n97|13\n\n75,47,61\n"
+	file := filepath.Join(t.TempDir(), "input.txt")
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+	expected := []string{"47|53", "97|13", "", "75,47,61"}
+
+	for name, mmap := range map[string]bool{"stream": false, "mmap": true} {
+		t.Run(name, func(t *testing.T) {
+			f, err := reader.Open(file, reader.WithMmap(mmap))
+			require.NoError(t, err)
+			assert.Equal(t, mmap, f.Bytes() != nil)
+
+			lines, err := reader.NewInput(f).Lines()
+			require.NoError(t, err)
+			assert.Equal(t, expected, lines)
+			require.NoError(t, f.Close())
+		})
+	}
+
+	lines, err := reader.NewInput(reader.FromBytes([]byte(content))).Lines()
+	require.NoError(t, err)
+	assert.Equal(t, expected, lines)
+
+	// the file is closed on return, its lines must outlive the mapping
+	lines, err = reader.FileReadlines(file, reader.WithMmap(true))
+	require.NoError(t, err)
+	assert.Equal(t, expected, lines)
+	in, err := reader.FileInput(file, reader.WithMmap(true))
+	require.NoError(t, err)
+	lines, err = in.Lines()
+	require.NoError(t, err)
+	assert.Equal(t, expected, lines)
+
+	empty := filepath.Join(t.TempDir(), "empty.txt")
+	require.NoError(t, os.WriteFile(empty, nil, 0o600))
+	f, err := reader.Open(empty, reader.WithMmap(true))
+	require.NoError(t, err)
+	defer f.Close()
+	lines, err = reader.NewInput(f).Lines()
+	require.NoError(t, err)
+	assert.Empty(t, lines)
+}
+
+// TestMaxLineSize tests that lines longer than the max line size fail at their line
+func TestMaxLineSize(t *testing.T) {
+	long := strings.Repeat("x", 100<<10)
+	input := "short\n" + long + "\n"
+
+	lines, err := reader.NewInput(strings.NewReader(input)).Lines()
+	require.NoError(t, err, "Expected lines longer than the default buffer to be read")
+	assert.Equal(t, long, lines[1])
+
+	_, err = reader.NewInput(strings.NewReader(input), reader.WithBufferSize(16), reader.WithMaxLineSize(1<<10)).Lines()
+	var parseErr *reader.ParseError
+	require.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, 2, parseErr.Line)
+
+	f := reader.FromBytes([]byte(input), reader.WithMaxLineSize(1<<10))
+	_, err = reader.NewInput(strings.NewReader(input), reader.WithMaxLineSize(1<<10)).Ints()
+	assert.Error(t, err, "Expected the error to reach every method")
+	text, err := reader.NewInput(f).Text()
+	require.NoError(t, err)
+	assert.Equal(t, input, text)
+}
@@ -0,0 +1,240 @@
+package reader
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unsafe"
+)
+
+// Input is a puzzle input read from any io.Reader, it is read on first use and its lines are kept
+// so every method can be called more than once. Line numbers start at 1.
+type Input struct {
+	r     io.Reader
+	opts  Options
+	lines []string
+	err   error
+	read  bool
+	text  *string // text is the content read by Text
+}
+
+// ErrReadAsLines is the error of Text once a streamed input was read as lines, their line endings are gone
+var ErrReadAsLines = errors.New("input already read as lines")
+
+// NewInput returns the Input read from r with opts, the options of a File opened by Open apply first
+func NewInput(r io.Reader, opts ...OptFunc) *Input {
+	o := newOptions()
+	if f, ok := r.(*File); ok {
+		o = f.opts
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Input{r: r, opts: o}
+}
+
+// FileInput reads the Input of filename, Stdin reads from standard input
+func FileInput(filename string, opts ...OptFunc) (*Input, error) {
+	file, err := Open(filename, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	return NewInput(FromBytes(content, opts...)), nil
+}
+
+// Lines returns every line of the input without line endings, the lines of a File held in memory are not copied
+func (in *Input) Lines() ([]string, error) {
+	if in.read {
+		return in.lines, in.err
+	}
+	in.read = true
+
+	if f, ok := in.r.(*File); ok && f.data != nil {
+		in.lines = splitLines(f.data)
+		return in.lines, nil
+	}
+	if in.text != nil {
+		in.lines = splitLines(unsafe.Slice(unsafe.StringData(*in.text), len(*in.text)))
+		return in.lines, nil
+	}
+	in.lines, in.err = Readlines(in.r, WithBufferSize(in.opts.BufferSize), WithMaxLineSize(in.opts.MaxLineSize))
+	return in.lines, in.err
+}
+
+// Text returns the whole input, the content of a File held in memory is not copied.
+// The other methods can be called after Text, Text fails with ErrReadAsLines after them on a stream.
+func (in *Input) Text() (string, error) {
+	if f, ok := in.r.(*File); ok && f.data != nil {
+		return unsafe.String(unsafe.SliceData(f.data), len(f.data)), nil
+	}
+	if in.text != nil {
+		return *in.text, nil
+	}
+	if in.read {
+		return "", ErrReadAsLines
+	}
+
+	content, err := io.ReadAll(in.r)
+	if err != nil {
+		return "", err
+	}
+	text := string(content)
+	in.text = &text
+	return text, nil
+}
+
+// splitLines splits data into lines like bufio.ScanLines, the lines share the memory of data
+func splitLines(data []byte) []string {
+	if len(data) == 0 {
+		return nil
+	}
+	text := unsafe.String(unsafe.SliceData(data), len(data))
+
+	lines := make([]string, 0, strings.Count(text, "\n")+1)
+	for text != "" {
+		line, rest, _ := strings.Cut(text, "\n")
+		lines = append(lines, strings.TrimSuffix(line, "\r"))
+		text = rest
+	}
+	return lines
+}
+
+// Section is a block of lines separated by blank lines, Start is the line number of its first line
+type Section struct {
+	Start int
+	Lines []string
+}
+
+// Sections returns the blocks of lines separated by one or more blank lines
+func (in *Input) Sections() ([]Section, error) {
+	lines, err := in.Lines()
+	if err != nil {
+		return nil, err
+	}
+
+	sections := []Section{}
+	for i, line := range lines {
+		switch {
+		case line == "":
+		case i == 0 || lines[i-1] == "":
+			sections = append(sections, Section{Start: i + 1, Lines: []string{line}})
+		default:
+			last := &sections[len(sections)-1]
+			last.Lines = append(last.Lines, line)
+		}
+	}
+	return sections, nil
+}
+
+// Grid is a rectangle of bytes indexed by row then column
+type Grid [][]byte
+
+// Height returns the number of rows
+func (g Grid) Height() int {
+	return len(g)
+}
+
+// Width returns the number of columns
+func (g Grid) Width() int {
+	if len(g) == 0 {
+		return 0
+	}
+	return len(g[0])
+}
+
+// In reports whether column x of row y is inside the grid
+func (g Grid) In(x, y int) bool {
+	return y >= 0 && y < g.Height() && x >= 0 && x < g.Width()
+}
+
+// Grid returns the lines of the input as a Grid, every row must have the same number of columns
+func (in *Input) Grid() (Grid, error) {
+	lines, err := in.Lines()
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, errors.New("empty grid")
+	}
+
+	grid := make(Grid, len(lines))
+	for y, line := range lines {
+		if len(line) != len(lines[0]) {
+			return nil, Errorf(y+1, 0, "row has %d columns, expected %d", len(line), len(lines[0]))
+		}
+		grid[y] = []byte(line)
+	}
+	return grid, nil
+}
+
+// IntFields returns the integers of every line separated by any whitespace, a blank line has no integers
+func (in *Input) IntFields() ([][]int, error) {
+	lines, err := in.Lines()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([][]int, 0, len(lines))
+	for i, line := range lines {
+		ints, err := ParseInts(line, i+1, 1)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, ints)
+	}
+	return fields, nil
+}
+
+// Ints returns every integer of the input separated by any whitespace, including line breaks
+func (in *Input) Ints() ([]int, error) {
+	fields, err := in.IntFields()
+	if err != nil {
+		return nil, err
+	}
+
+	ints := []int{}
+	for _, f := range fields {
+		ints = append(ints, f...)
+	}
+	return ints, nil
+}
+
+// ParseInts returns the integers of text separated by any whitespace. text is part of the input
+// at line starting at column, the position of an invalid integer is reported in a ParseError.
+func ParseInts(text string, line, column int) ([]int, error) {
+	words, offsets := fields(text)
+	ints := make([]int, 0, len(words))
+	for i, word := range words {
+		v, err := strconv.Atoi(word)
+		if err != nil {
+			return nil, Errorf(line, column+offsets[i], "invalid integer %q", word)
+		}
+		ints = append(ints, v)
+	}
+	return ints, nil
+}
+
+// fields splits text around runs of whitespace like strings.Fields, it returns the offset of every field in text too
+func fields(text string) ([]string, []int) {
+	words, offsets := []string{}, []int{}
+	start := -1
+	for i, r := range text + " " {
+		switch {
+		case !unicode.IsSpace(r) && start < 0:
+			start = i
+		case unicode.IsSpace(r) && start >= 0:
+			words = append(words, text[start:i])
+			offsets = append(offsets, start)
+			start = -1
+		}
+	}
+	return words, offsets
+}
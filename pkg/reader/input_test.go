@@ -0,0 +1,105 @@
+package reader_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"aoc2024/pkg/reader"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestInputLines tests that method Lines can be called more than once
+func TestInputLines(t *testing.T) {
+	in := reader.NewInput(strings.NewReader("a\r\nb\n\nc\n"))
+	for range 2 {
+		lines, err := in.Lines()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "", "c"}, lines)
+	}
+}
+
+// TestInputText tests that method Text can be combined with the other methods
+func TestInputText(t *testing.T) {
+	in := reader.NewInput(strings.NewReader("a\r\nb\n"))
+	text, err := in.Text()
+	require.NoError(t, err)
+	assert.Equal(t, "a\r\nb\n", text)
+	lines, err := in.Lines()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, lines)
+	text, err = in.Text()
+	require.NoError(t, err)
+	assert.Equal(t, "a\r\nb\n", text)
+
+	in = reader.NewInput(strings.NewReader("a\nb\n"))
+	_, err = in.Grid()
+	require.NoError(t, err)
+	_, err = in.Text()
+	assert.ErrorIs(t, err, reader.ErrReadAsLines)
+
+	in = reader.NewInput(reader.FromBytes([]byte("a\nb\n")))
+	_, err = in.Lines()
+	require.NoError(t, err)
+	text, err = in.Text()
+	require.NoError(t, err)
+	assert.Equal(t, "a\nb\n", text, "Expected a File in memory to keep its text")
+}
+
+// TestInputSections tests for method Sections
+func TestInputSections(t *testing.T) {
+	sections, err := reader.NewInput(strings.NewReader("47|53\n97|13\n\n\n75,47\n\n")).Sections()
+	require.NoError(t, err)
+	assert.Equal(t, []reader.Section{
+		{Start: 1, Lines: []string{"47|53", "97|13"}},
+		{Start: 5, Lines: []string{"75,47"}},
+	}, sections)
+}
+
+// TestInputGrid tests for method Grid
+func TestInputGrid(t *testing.T) {
+	grid, err := reader.NewInput(strings.NewReader("..#\n#..\n")).Grid()
+	require.NoError(t, err)
+	assert.Equal(t, 3, grid.Width())
+	assert.Equal(t, 2, grid.Height())
+	assert.Equal(t, byte('#'), grid[1][0])
+	assert.True(t, grid.In(2, 1))
+	assert.False(t, grid.In(3, 0))
+
+	_, err = reader.NewInput(strings.NewReader("..#\n#.\n")).Grid()
+	assert.EqualError(t, err, "line 2: row has 2 columns, expected 3")
+	_, err = reader.NewInput(strings.NewReader("")).Grid()
+	assert.Error(t, err, "Expected empty grid to be rejected")
+}
+
+// TestInputInts tests for methods Ints and IntFields
+func TestInputInts(t *testing.T) {
+	fields, err := reader.NewInput(strings.NewReader("3   4\n4\t -3\n\n")).IntFields()
+	require.NoError(t, err)
+	assert.Equal(t, [][]int{{3, 4}, {4, -3}, {}}, fields)
+
+	ints, err := reader.NewInput(strings.NewReader("3   4\n4\t -3\n")).Ints()
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 4, 4, -3}, ints)
+
+	_, err = reader.NewInput(strings.NewReader("1 2\n3  x4\n")).IntFields()
+	assert.EqualError(t, err, `line 2 column 4: invalid integer "x4"`)
+
+	_, err = reader.ParseInts("1 y", 7, 5)
+	assert.EqualError(t, err, `line 7 column 7: invalid integer "y"`)
+}
+
+// TestFileReadlines tests that function FileReadlines returns the open error
+func TestFileReadlines(t *testing.T) {
+	_, err := reader.FileReadlines(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	file := filepath.Join(t.TempDir(), "input.txt")
+	require.NoError(t, os.WriteFile(file, []byte("a\nb\n"), 0o600))
+	lines, err := reader.FileReadlines(file)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, lines)
+}
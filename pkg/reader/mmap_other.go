@@ -0,0 +1,17 @@
+//go:build !unix
+
+package reader
+
+import (
+	"io"
+	"os"
+)
+
+// mmap reads size bytes of file into memory where memory mapping isn't supported
+func mmap(file *os.File, size int) (data []byte, unmap func() error, err error) {
+	data = make([]byte, size)
+	if _, err := io.ReadFull(file, data); err != nil {
+		return nil, nil, err
+	}
+	return data, func() error { return nil }, nil
+}
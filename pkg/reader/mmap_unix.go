@@ -0,0 +1,23 @@
+//go:build unix
+
+package reader
+
+import (
+	"os"
+	"syscall"
+)
+
+// mmap maps size bytes of file read only, unmap releases the mapping
+func mmap(file *os.File, size int) (data []byte, unmap func() error, err error) {
+	if size == 0 {
+		return []byte{}, func() error { return nil }, nil
+	}
+
+	data, err = syscall.Mmap(int(file.Fd()), 0, size, syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, &os.PathError{Op: "mmap", Path: file.Name(), Err: err}
+	}
+	return data, func() error {
+		return syscall.Munmap(data)
+	}, nil
+}
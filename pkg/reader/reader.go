@@ -2,33 +2,157 @@ package reader
 
 import (
 	"bufio"
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
-func FileReadlines(filename string) ([]string, error) {
-	file, err := os.Open(filename)
+// Stdin is the filename reading from standard input
+const Stdin = "-"
+
+const (
+	// DefaultBufferSize is the initial size of the line buffer
+	DefaultBufferSize = 64 << 10
+	// DefaultMaxLineSize is the longest line read, the line buffer grows up to it
+	DefaultMaxLineSize = 256 << 20
+)
+
+// Options of reading a puzzle input
+type Options struct {
+	BufferSize  int  // BufferSize is the initial size of the line buffer
+	MaxLineSize int  // MaxLineSize is the longest line read
+	Mmap        bool // Mmap maps regular files into memory instead of reading them
+}
+
+// OptFunc sets an option of reading a puzzle input
+type OptFunc func(*Options)
+
+// WithBufferSize set options initial line buffer size
+func WithBufferSize(size int) OptFunc {
+	return func(o *Options) {
+		o.BufferSize = size
+	}
+}
+
+// WithMaxLineSize set options longest line read
+func WithMaxLineSize(size int) OptFunc {
+	return func(o *Options) {
+		o.MaxLineSize = size
+	}
+}
+
+// WithMmap set options memory mapping of regular files
+func WithMmap(mmap bool) OptFunc {
+	return func(o *Options) {
+		o.Mmap = mmap
+	}
+}
+
+// newOptions returns the default options overridden by opts
+func newOptions(opts ...OptFunc) Options {
+	o := Options{
+		BufferSize:  DefaultBufferSize,
+		MaxLineSize: DefaultMaxLineSize,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	o.BufferSize = max(min(o.BufferSize, o.MaxLineSize), 1)
+	return o
+}
+
+// Open opens filename for reading, Stdin reads from standard input.
+// Files ending in .gz or .bz2 and compressed stdin, detected by its magic bytes, are decompressed.
+// Compressed files are read as a stream even with mmap. The returned File carries opts to the Input of the file.
+func Open(filename string, opts ...OptFunc) (*File, error) {
+	o := newOptions(opts...)
+	if filename == Stdin {
+		compression, r, err := Sniff(os.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		return decompressed(r, compression, func() error { return nil }, o)
+	}
+
+	file, err := os.Open(filepath.Clean(filename))
 	if err != nil {
-		return []string{}, nil
+		return nil, err
+	}
+	if compression := CompressionOf(filename); compression != NoCompression {
+		return decompressed(file, compression, file.Close, o)
+	}
+	if o.Mmap {
+		return mapFile(file, o)
 	}
+	return &File{r: file, close: file.Close, opts: o}, nil
+}
 
-	defer file.Close()
+// decompressed returns the File of the content of r decompressed, closing it closes the decompressor then r with closeR
+func decompressed(r io.Reader, compression Compression, closeR func() error, o Options) (*File, error) {
+	content, closeDecompressor, err := Decompress(r, compression)
+	if err != nil {
+		_ = closeR()
+		return nil, fmt.Errorf("%s input: %w", strings.TrimPrefix(string(compression), "."), err)
+	}
+	return &File{
+		r: content,
+		close: func() error {
+			err := closeDecompressor()
+			if closeErr := closeR(); err == nil {
+				err = closeErr
+			}
+			return err
+		},
+		opts: o,
+	}, nil
+}
 
-	filescanner := bufio.NewScanner(file)
-	filescanner.Split(bufio.ScanLines)
+// Scanner returns a line scanner of r with the line buffer of opts
+func Scanner(r io.Reader, opts ...OptFunc) *bufio.Scanner {
+	o := newOptions(opts...)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, o.BufferSize), o.MaxLineSize)
+	scanner.Split(bufio.ScanLines)
+	return scanner
+}
+
+// Readlines reads all lines of r, a line longer than the max line size is a ParseError
+func Readlines(r io.Reader, opts ...OptFunc) ([]string, error) {
+	o := newOptions(opts...)
+	filescanner := Scanner(r, WithBufferSize(o.BufferSize), WithMaxLineSize(o.MaxLineSize))
 	var content []string
 
 	for filescanner.Scan() {
 		content = append(content, filescanner.Text())
 	}
 
-	return content, nil
+	err := filescanner.Err()
+	if errors.Is(err, bufio.ErrTooLong) {
+		return content, &ParseError{Line: len(content) + 1, Err: err}
+	}
+	return content, err
 }
 
-func FileScanner(filename string) (*bufio.Scanner, error) {
-	file, err := os.Open(filename)
+// FileReadlines reads all lines of filename, the lines are copied as a memory mapped file is closed on return
+func FileReadlines(filename string, opts ...OptFunc) ([]string, error) {
+	file, err := Open(filename, opts...)
 	if err != nil {
 		return nil, err
 	}
+	defer file.Close()
+
+	return Readlines(file, opts...)
+}
+
+// FileScanner returns a line scanner of filename and the function closing the file once scanned
+func FileScanner(filename string, opts ...OptFunc) (*bufio.Scanner, func() error, error) {
+	file, err := Open(filename, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	return bufio.NewScanner(file), nil
+	return Scanner(file, opts...), file.Close, nil
 }
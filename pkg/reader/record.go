@@ -0,0 +1,278 @@
+package reader
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// RecordTag names the placeholder of a struct field, the field name is used without it
+const RecordTag = "record"
+
+// Record parses lines onto the struct T with a format of literal text and {Field} placeholders, e.g.
+//
+//	"{Test}: {Equation...}" parses "190: 10 19" onto struct{ Test int; Equation []int }
+//
+// Whitespace in the format matches any run of whitespace. {Field...} fills a slice with the values
+// separated by whitespace, {Field...,} with the values separated by a comma or another separator,
+// a slice needs at least one value. Fields are int, uint, string or slices of them.
+type Record[T any] struct {
+	format string
+	tokens []token
+}
+
+// token of a Record format, a literal when field is nil
+type token struct {
+	literal string
+	space   bool
+	field   *recordField
+}
+
+// recordField is a placeholder of a Record format
+type recordField struct {
+	name  string
+	index []int
+	kind  reflect.Kind // kind of the field, of its elements for a slice
+	slice bool
+	sep   string // separator of slice elements, any whitespace when empty
+}
+
+// NewRecord compiles the format of Record parsing lines onto T
+func NewRecord[T any](format string) (*Record[T], error) {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("record %q: %s is not a struct", format, typ)
+	}
+
+	tokens, err := compile(format, typ)
+	if err != nil {
+		return nil, fmt.Errorf("record %q: %w", format, err)
+	}
+	return &Record[T]{format: format, tokens: tokens}, nil
+}
+
+// MustRecord is like NewRecord but panics on an invalid format, for package level records
+func MustRecord[T any](format string) *Record[T] {
+	r, err := NewRecord[T](format)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// compile splits format into literals, whitespace and the placeholders of the fields of typ
+func compile(format string, typ reflect.Type) ([]token, error) {
+	fields := map[string][]int{}
+	for _, f := range reflect.VisibleFields(typ) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		name := f.Name
+		if tag, ok := f.Tag.Lookup(RecordTag); ok {
+			name = tag
+		}
+		fields[name] = f.Index
+	}
+
+	tokens := []token{}
+	for format != "" {
+		switch {
+		case format[0] == '{':
+			end := strings.IndexByte(format, '}')
+			if end < 0 {
+				return nil, errors.New("unclosed {")
+			}
+			field, err := placeholder(format[1:end], typ, fields)
+			if err != nil {
+				return nil, err
+			}
+			if len(tokens) > 0 && tokens[len(tokens)-1].field != nil {
+				return nil, fmt.Errorf("placeholders {%s} and {%s} must be separated", tokens[len(tokens)-1].field.name, field.name)
+			}
+			tokens = append(tokens, token{field: field})
+			format = format[end+1:]
+		case unicode.IsSpace(rune(format[0])):
+			tokens = append(tokens, token{space: true})
+			format = strings.TrimLeftFunc(format, unicode.IsSpace)
+		default:
+			end := strings.IndexFunc(format, func(r rune) bool {
+				return r == '{' || unicode.IsSpace(r)
+			})
+			if end < 0 {
+				end = len(format)
+			}
+			tokens = append(tokens, token{literal: format[:end]})
+			format = format[end:]
+		}
+	}
+
+	// a whitespace separated slice can only end at a literal or at the end of the line
+	for i, t := range tokens {
+		if t.field != nil && t.field.slice && t.field.sep == "" && i+1 < len(tokens) && tokens[i+1].space {
+			return nil, fmt.Errorf("placeholder {%s...} must not be followed by whitespace", t.field.name)
+		}
+	}
+	return tokens, nil
+}
+
+// placeholder returns the field of typ named by the placeholder spec e.g. Test, Equation... or Pages...,
+func placeholder(spec string, typ reflect.Type, fields map[string][]int) (*recordField, error) {
+	name, sep, slice := strings.Cut(spec, "...")
+	index, ok := fields[name]
+	if !ok {
+		return nil, fmt.Errorf("placeholder {%s}: %s has no field %s", spec, typ, name)
+	}
+
+	f := typ.FieldByIndex(index)
+	kind := f.Type.Kind()
+	if slice != (kind == reflect.Slice) {
+		return nil, fmt.Errorf("placeholder {%s}: field %s is %s, slices are filled by {%s...}", spec, f.Name, f.Type, name)
+	}
+	if slice {
+		kind = f.Type.Elem().Kind()
+	}
+	if !supported(kind) {
+		return nil, fmt.Errorf("placeholder {%s}: unsupported type %s", spec, f.Type)
+	}
+	return &recordField{name: name, index: index, kind: kind, slice: slice, sep: sep}, nil
+}
+
+// supported reports whether values of kind are converted by Record
+func supported(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.String:
+		return true
+	}
+	return false
+}
+
+// Format returns the format of the record
+func (r *Record[T]) Format() string {
+	return r.format
+}
+
+// Parse parses the line with number onto a T, a ParseError locates the mismatch
+func (r *Record[T]) Parse(line string, number int) (T, error) {
+	var record T
+	value := reflect.ValueOf(&record).Elem()
+
+	pos := 0
+	for i, t := range r.tokens {
+		column := pos + 1
+		switch {
+		case t.space:
+			n := len(line[pos:]) - len(strings.TrimLeftFunc(line[pos:], unicode.IsSpace))
+			if n == 0 {
+				return record, Errorf(number, column, "expected whitespace")
+			}
+			pos += n
+		case t.field == nil:
+			if !strings.HasPrefix(line[pos:], t.literal) {
+				return record, Errorf(number, column, "expected %q", t.literal)
+			}
+			pos += len(t.literal)
+		default:
+			end, ok := r.fieldEnd(line, pos, i)
+			if !ok {
+				return record, Errorf(number, column, "expected %q after {%s}", r.tokens[i+1].literal, t.field.name)
+			}
+			if err := t.field.set(value.FieldByIndex(t.field.index), line[pos:end], number, column); err != nil {
+				return record, err
+			}
+			pos = end
+		}
+	}
+
+	if pos < len(line) {
+		return record, Errorf(number, pos+1, "unexpected %q", line[pos:])
+	}
+	return record, nil
+}
+
+// ParseLines parses every line onto a T, start is the line number of the first line
+func (r *Record[T]) ParseLines(lines []string, start int) ([]T, error) {
+	records := make([]T, 0, len(lines))
+	for i, line := range lines {
+		record, err := r.Parse(line, start+i)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// fieldEnd returns where the placeholder token i starting at pos ends in line, at the next literal
+// or whitespace following it or at the end of the line. It reports whether the next literal was found.
+func (r *Record[T]) fieldEnd(line string, pos, i int) (int, bool) {
+	if i+1 == len(r.tokens) {
+		return len(line), true
+	}
+
+	next := r.tokens[i+1]
+	if next.space {
+		if end := strings.IndexFunc(line[pos:], unicode.IsSpace); end >= 0 {
+			return pos + end, true
+		}
+		return len(line), true
+	}
+	end := strings.Index(line[pos:], next.literal)
+	return pos + end, end >= 0
+}
+
+// set converts text at column of line number and stores it in v
+func (f *recordField) set(v reflect.Value, text string, number, column int) error {
+	if !f.slice {
+		return convert(v, f.kind, text, f.name, number, column)
+	}
+
+	elements, offsets := []string{}, []int{}
+	if f.sep == "" {
+		elements, offsets = fields(text)
+	} else if text != "" {
+		offset := 0
+		for _, element := range strings.Split(text, f.sep) {
+			elements = append(elements, element)
+			offsets = append(offsets, offset)
+			offset += len(element) + len(f.sep)
+		}
+	}
+	if len(elements) == 0 {
+		return Errorf(number, column, "expected at least one value for {%s...%s}", f.name, f.sep)
+	}
+
+	slice := reflect.MakeSlice(v.Type(), len(elements), len(elements))
+	for i, element := range elements {
+		if err := convert(slice.Index(i), f.kind, element, f.name, number, column+offsets[i]); err != nil {
+			return err
+		}
+	}
+	v.Set(slice)
+	return nil
+}
+
+// convert parses text as a value of kind and stores it in v
+func convert(v reflect.Value, kind reflect.Kind, text, name string, number, column int) error {
+	switch kind {
+	case reflect.String:
+		v.SetString(text)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(text, 10, v.Type().Bits())
+		if err != nil {
+			return Errorf(number, column, "invalid %s %q for %s", kind, text, name)
+		}
+		v.SetUint(u)
+	default:
+		n, err := strconv.ParseInt(text, 10, v.Type().Bits())
+		if err != nil {
+			return Errorf(number, column, "invalid %s %q for %s", kind, text, name)
+		}
+		v.SetInt(n)
+	}
+	return nil
+}
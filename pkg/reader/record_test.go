@@ -0,0 +1,89 @@
+package reader_test
+
+import (
+	"testing"
+
+	"aoc2024/pkg/reader"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type equation struct {
+	Test     int
+	Equation []int
+}
+
+type rule struct {
+	Before uint8  `record:"before"`
+	After  uint8  `record:"after"`
+	Name   string `record:"name"`
+}
+
+type update struct {
+	Pages []int
+}
+
+// TestRecord tests for method Parse
+func TestRecord(t *testing.T) {
+	equations := reader.MustRecord[equation]("{Test}: {Equation...}")
+	e, err := equations.Parse("3267: 81 40  27", 1)
+	require.NoError(t, err)
+	assert.Equal(t, equation{Test: 3267, Equation: []int{81, 40, 27}}, e)
+
+	rules := reader.MustRecord[rule]("{name} {before}|{after}")
+	r, err := rules.Parse("first\t47|53", 1)
+	require.NoError(t, err)
+	assert.Equal(t, rule{Before: 47, After: 53, Name: "first"}, r)
+
+	updates, err := reader.MustRecord[update]("{Pages...,}").ParseLines([]string{"75,47,61", "97"}, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []update{{Pages: []int{75, 47, 61}}, {Pages: []int{97}}}, updates)
+	_, err = reader.MustRecord[update]("{Pages...,}").ParseLines([]string{"75,47,61", ""}, 3)
+	assert.EqualError(t, err, `line 4 column 1: expected at least one value for {Pages...,}`)
+
+	scenarios := map[string]struct {
+		line     string
+		expected string
+	}{
+		"invalid integer":    {line: "3267: 81 4x 27", expected: `line 5 column 10: invalid int "4x" for Equation`},
+		"missing literal":    {line: "3267 81", expected: `line 5 column 1: expected ":" after {Test}`},
+		"invalid test value": {line: "x: 81", expected: `line 5 column 1: invalid int "x" for Test`},
+		"empty equation":     {line: "190: ", expected: `line 5 column 6: expected at least one value for {Equation...}`},
+	}
+	for name, s := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			_, err := equations.Parse(s.line, 5)
+			assert.EqualError(t, err, s.expected)
+		})
+	}
+
+	_, err = rules.Parse("first 47|300", 2)
+	assert.EqualError(t, err, `line 2 column 10: invalid uint8 "300" for after`)
+	_, err = rules.Parse("first 47-53", 2)
+	assert.EqualError(t, err, `line 2 column 7: expected "|" after {before}`)
+	_, err = rules.Parse("first", 2)
+	assert.EqualError(t, err, `line 2 column 6: expected whitespace`)
+	_, err = reader.MustRecord[rule]("{before}|{after}!").Parse("1|2!?", 4)
+	assert.EqualError(t, err, `line 4 column 5: unexpected "?"`)
+}
+
+// TestNewRecord tests that function NewRecord rejects invalid formats
+func TestNewRecord(t *testing.T) {
+	for name, format := range map[string]string{
+		"unknown field":     "{Test}: {Equations...}",
+		"unclosed":          "{Test: {Equation...}",
+		"adjacent":          "{Test}{Equation...}",
+		"slice without ...": "{Test}: {Equation}",
+		"int with ...":      "{Test...}: {Equation...}",
+		"ambiguous slice":   "{Equation...} {Test}",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := reader.NewRecord[equation](format)
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := reader.NewRecord[int]("{Test}")
+	assert.Error(t, err, "Expected non struct to be rejected")
+}
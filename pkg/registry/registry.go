@@ -0,0 +1,123 @@
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"aoc2024/pkg/solver"
+)
+
+const (
+	// DefaultYear the year of the registered solvers and of the CLI when none is given
+	DefaultYear = 2024
+	// FirstYear the first year of Advent of Code
+	FirstYear = 2015
+	// MaxDay the last day of Advent of Code
+	MaxDay = 25
+)
+
+// Entry is a registered solver together with its metadata
+type Entry struct {
+	Year  int
+	Day   int
+	Title string
+	Parts int      // Parts is the number of implemented parts, part 1 up to Parts
+	Tags  []string // Tags describes the puzzle e.g. "grid" or "parsing"
+	New   func() solver.Solver
+}
+
+// PartNumbers returns the implemented parts in order
+func (e Entry) PartNumbers() []int {
+	parts := make([]int, 0, e.Parts)
+	for part := 1; part <= e.Parts; part++ {
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+// Key identifies a puzzle by its year and day
+type Key struct {
+	Year int
+	Day  int
+}
+
+var (
+	mu      sync.RWMutex
+	entries = map[Key]Entry{}
+)
+
+// Register makes a solver available by its year and day, it is meant to be called from the init function of a day package.
+// The year defaults to DefaultYear. Register panics if the day is registered twice or the entry is incomplete.
+func Register(entry Entry) {
+	if entry.Year == 0 {
+		entry.Year = DefaultYear
+	}
+	if entry.Year < FirstYear {
+		panic(fmt.Sprintf("registry: day %d registered in year %d before %d", entry.Day, entry.Year, FirstYear))
+	}
+	if entry.New == nil {
+		panic(fmt.Sprintf("registry: %d day %d registered without a solver", entry.Year, entry.Day))
+	}
+	if entry.Parts < 1 || entry.Parts > solver.Part2 {
+		panic(fmt.Sprintf("registry: %d day %d registered with %d parts", entry.Year, entry.Day, entry.Parts))
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	key := Key{Year: entry.Year, Day: entry.Day}
+	if _, ok := entries[key]; ok {
+		panic(fmt.Sprintf("registry: %d day %d registered twice", entry.Year, entry.Day))
+	}
+	entries[key] = entry
+}
+
+// Lookup returns the entry of day in year
+func Lookup(year, day int) (Entry, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	entry, ok := entries[Key{Year: year, Day: day}]
+	return entry, ok
+}
+
+// Entries returns all registered entries ordered by year and day
+func Entries() []Entry {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	all := make([]Entry, 0, len(entries))
+	for _, entry := range entries {
+		all = append(all, entry)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Year != all[j].Year {
+			return all[i].Year < all[j].Year
+		}
+		return all[i].Day < all[j].Day
+	})
+	return all
+}
+
+// Days returns all registered days of year in order
+func Days(year int) []int {
+	days := []int{}
+	for _, entry := range Entries() {
+		if entry.Year == year {
+			days = append(days, entry.Day)
+		}
+	}
+	return days
+}
+
+// Years returns every year with a registered day in order
+func Years() []int {
+	years := []int{}
+	for _, entry := range Entries() {
+		if len(years) == 0 || years[len(years)-1] != entry.Year {
+			years = append(years, entry.Year)
+		}
+	}
+	return years
+}
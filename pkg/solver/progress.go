@@ -0,0 +1,46 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+type progressKey struct{}
+
+// Progress tracks how far a running part got, it is safe for concurrent use
+type Progress struct {
+	done  atomic.Int64
+	total atomic.Int64
+}
+
+// WithProgress returns a copy of ctx carrying a new Progress for a part to report to
+func WithProgress(ctx context.Context) (context.Context, *Progress) {
+	progress := &Progress{}
+	return context.WithValue(ctx, progressKey{}, progress), progress
+}
+
+// ReportProgress records that done of total steps of the running part are finished.
+// It does nothing if ctx carries no Progress, so parts can always report.
+func ReportProgress(ctx context.Context, done, total int) {
+	progress, ok := ctx.Value(progressKey{}).(*Progress)
+	if !ok {
+		return
+	}
+	progress.done.Store(int64(done))
+	progress.total.Store(int64(total))
+}
+
+// Load returns the last reported done and total steps
+func (p *Progress) Load() (done, total int) {
+	return int(p.done.Load()), int(p.total.Load())
+}
+
+// String describes the progress e.g. "1234/5000 (24.7%)", empty if nothing was reported
+func (p *Progress) String() string {
+	done, total := p.Load()
+	if total == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d (%.1f%%)", done, total, float64(done)*100/float64(total)) //nolint:mnd // percentage
+}
@@ -0,0 +1,170 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"runtime/pprof"
+	"strconv"
+	"time"
+)
+
+// Stages of a solver, Parse is always run before any part.
+const (
+	PartParse = 0
+	Part1     = 1
+	Part2     = 2
+)
+
+// ErrNotImplemented is returned by a part that isn't solved yet
+var ErrNotImplemented = errors.New("not implemented")
+
+// Solver solves a single day of Advent of Code in separate stages.
+// Parse reads the puzzle input and must be called before Part1 or Part2.
+// The parts must not modify the parsed input, so they can be run in any order and repeatedly.
+// Long running parts should stop with the context error once ctx is done and report their progress with ReportProgress.
+type Solver interface {
+	Parse(r io.Reader) error
+	Part1(ctx context.Context) (Answer, error)
+	Part2(ctx context.Context) (Answer, error)
+}
+
+// PartFn solves a single part
+type PartFn func(ctx context.Context) (Answer, error)
+
+// Answer holds the answer of a part, either an int, a string or a big value.
+type Answer struct {
+	value any
+}
+
+// Int returns an Answer of an int
+func Int(v int) Answer {
+	return Answer{value: v}
+}
+
+// String returns an Answer of a string
+func String(v string) Answer {
+	return Answer{value: v}
+}
+
+// Big returns an Answer of a big int
+func Big(v *big.Int) Answer {
+	return Answer{value: v}
+}
+
+// Value returns the underlying answer value, nil if no answer was given.
+func (a Answer) Value() any {
+	return a.value
+}
+
+// IsZero reports whether the answer is missing
+func (a Answer) IsZero() bool {
+	return a.value == nil
+}
+
+// String returns the answer formatted as a string, empty if no answer was given.
+func (a Answer) String() string {
+	switch v := a.value.(type) {
+	case int:
+		return strconv.Itoa(v)
+	case string:
+		return v
+	case *big.Int:
+		return v.String()
+	}
+	return ""
+}
+
+// Result is the outcome of a single stage of a solver.
+type Result struct {
+	Part     int // Part is the solved part or PartParse for the parse stage
+	Answer   Answer
+	Duration time.Duration
+	Err      error
+}
+
+// Stage returns the name of the stage the result belongs to
+func (r Result) Stage() string {
+	if r.Part == PartParse {
+		return "parse"
+	}
+	return "part " + strconv.Itoa(r.Part)
+}
+
+// Labeled runs fn with the pprof label "part" set to the stage of part, "parse" or the part number,
+// so profiles of several parts can be split with e.g. go tool pprof -tagfocus part=2
+func Labeled(ctx context.Context, part int, fn func(ctx context.Context)) {
+	label := strconv.Itoa(part)
+	if part == PartParse {
+		label = "parse"
+	}
+	pprof.Do(ctx, pprof.Labels("part", label), fn)
+}
+
+// Timed runs fn and returns its answer as a Result of part together with the duration it took.
+func Timed(part int, fn func() (Answer, error)) Result {
+	start := time.Now()
+	answer, err := fn()
+	return Result{
+		Part:     part,
+		Answer:   answer,
+		Duration: time.Since(start),
+		Err:      err,
+	}
+}
+
+// Solve parses the puzzle input r and runs the given parts of the solver in order.
+// The first Result is always the parse stage, if parsing fails no parts are run.
+// Once ctx is done the running part is cut off with its progress in the error and the remaining parts are skipped.
+func Solve(ctx context.Context, s Solver, r io.Reader, parts []int) []Result {
+	var parse Result
+	Labeled(ctx, PartParse, func(context.Context) {
+		parse = Timed(PartParse, func() (Answer, error) {
+			return Answer{}, s.Parse(r)
+		})
+	})
+	results := []Result{parse}
+	if parse.Err != nil {
+		return results
+	}
+
+	for _, part := range parts {
+		if err := ctx.Err(); err != nil {
+			results = append(results, Result{Part: part, Err: fmt.Errorf("skipped: %w", err)})
+			continue
+		}
+
+		var result Result
+		partCtx, progress := WithProgress(ctx)
+		Labeled(partCtx, part, func(ctx context.Context) {
+			result = Timed(part, func() (Answer, error) {
+				return PartFunc(s, part)(ctx)
+			})
+		})
+		if isContextError(result.Err) && progress.String() != "" {
+			result.Err = fmt.Errorf("%w after %s", result.Err, progress)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// isContextError reports whether err is caused by a canceled or expired context
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// PartFunc returns the method solving part of the solver
+func PartFunc(s Solver, part int) PartFn {
+	switch part {
+	case Part1:
+		return s.Part1
+	case Part2:
+		return s.Part2
+	}
+	return func(context.Context) (Answer, error) {
+		return Answer{}, fmt.Errorf("unknown part %d", part)
+	}
+}
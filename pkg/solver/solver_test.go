@@ -0,0 +1,109 @@
+package solver_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/big"
+	"strings"
+	"testing"
+
+	"aoc2024/pkg/solver"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countSolver counts the lines of the input, part 2 calls cancel after reporting progress of the first line
+type countSolver struct {
+	lines  []string
+	cancel context.CancelFunc
+}
+
+func (c *countSolver) Parse(r io.Reader) error {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if len(content) == 0 {
+		return errors.New("empty input")
+	}
+	c.lines = strings.Split(strings.TrimSpace(string(content)), "\n")
+	return nil
+}
+
+func (c *countSolver) Part1(_ context.Context) (solver.Answer, error) {
+	return solver.Int(len(c.lines)), nil
+}
+
+func (c *countSolver) Part2(ctx context.Context) (solver.Answer, error) {
+	for i := range c.lines {
+		if err := ctx.Err(); err != nil {
+			return solver.Answer{}, err
+		}
+		solver.ReportProgress(ctx, i+1, len(c.lines))
+		if c.cancel != nil {
+			c.cancel()
+		}
+	}
+	return solver.String(c.lines[0]), nil
+}
+
+// TestAnswer tests for type Answer
+func TestAnswer(t *testing.T) {
+	assert.Equal(t, "42", solver.Int(42).String())
+	assert.Equal(t, "abc", solver.String("abc").String())
+	huge, ok := new(big.Int).SetString("123456789012345678901234567890", 10)
+	require.True(t, ok)
+	assert.Equal(t, "123456789012345678901234567890", solver.Big(huge).String())
+	assert.True(t, solver.Answer{}.IsZero())
+	assert.Equal(t, "", solver.Answer{}.String())
+}
+
+// TestSolve tests for function Solve
+func TestSolve(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"Test Solve Success":     testSolveSuccess,
+		"Test Solve Parse Error": testSolveParseError,
+		"Test Solve Canceled":    testSolveCanceled,
+	} {
+		t.Run(scenario, func(t *testing.T) {
+			fn(t)
+		})
+	}
+}
+
+func testSolveSuccess(t *testing.T) {
+	results := solver.Solve(context.Background(), &countSolver{}, strings.NewReader("a\n"), []int{solver.Part1, solver.Part2})
+
+	require.Len(t, results, 3)
+	assert.Equal(t, solver.PartParse, results[0].Part)
+	assert.Equal(t, "parse", results[0].Stage())
+	assert.Equal(t, "1", results[1].Answer.String())
+	assert.Equal(t, "a", results[2].Answer.String())
+	for _, result := range results {
+		assert.NoError(t, result.Err)
+	}
+}
+
+func testSolveParseError(t *testing.T) {
+	results := solver.Solve(context.Background(), &countSolver{}, strings.NewReader(""), []int{solver.Part1})
+
+	require.Len(t, results, 1, "Expected parts to be skipped when parsing fails")
+	assert.Error(t, results[0].Err)
+}
+
+func testSolveCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	results := solver.Solve(ctx, &countSolver{cancel: cancel}, strings.NewReader("a\nb\nc\nd\n"),
+		[]int{solver.Part2, solver.Part1},
+	)
+
+	require.Len(t, results, 3)
+	assert.ErrorIs(t, results[1].Err, context.Canceled)
+	assert.Equal(t, "context canceled after 1/4 (25.0%)", results[1].Err.Error())
+	assert.ErrorIs(t, results[2].Err, context.Canceled)
+	assert.Contains(t, results[2].Err.Error(), "skipped", "Expected part to be skipped once canceled")
+}